@@ -0,0 +1,373 @@
+package gqbuilder
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// decodeFn builds a Go value from a GraphQL arg value
+type decodeFn func(param interface{}) (reflect.Value, error)
+
+// CoercionError is returned when an arg value cannot be converted to the Go
+// type of its field, Path is the arg path, e.g. filter.tags[1].limit
+type CoercionError struct {
+	Path   string
+	Value  interface{}
+	Type   reflect.Type
+	Reason string
+}
+
+func (e *CoercionError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("Argument value %v cannot be used as %s: %s", e.Value, e.Type, e.Reason)
+	}
+	return fmt.Sprintf("Argument %s value %v cannot be used as %s: %s", e.Path, e.Value, e.Type, e.Reason)
+}
+
+func coercionErrorf(param interface{}, t reflect.Type, format string, args ...interface{}) error {
+	return &CoercionError{Value: param, Type: t, Reason: fmt.Sprintf(format, args...)}
+}
+
+// withPath prefixes the path of a coercion error with a field name or an index
+func withPath(err error, segment string) error {
+	ce, ok := err.(*CoercionError)
+	if !ok {
+		return err
+	}
+	path := segment
+	if ce.Path != "" {
+		if ce.Path[0] == '[' {
+			path += ce.Path
+		} else {
+			path += "." + ce.Path
+		}
+	}
+	return &CoercionError{Path: path, Value: ce.Value, Type: ce.Type, Reason: ce.Reason}
+}
+
+// argsDecoders compiles a decoder once per Go type and caches it, struct
+// fields are looked up by the names of the naming strategy
+type argsDecoders struct {
+	naming   FieldNamingFn
+	mu       sync.RWMutex
+	decoders map[reflect.Type]decodeFn
+}
+
+type fieldDecoder struct {
+	name   string
+	index  []int
+	decode decodeFn
+}
+
+func newArgsDecoders(naming FieldNamingFn) *argsDecoders {
+	return &argsDecoders{
+		naming:   naming,
+		decoders: make(map[reflect.Type]decodeFn),
+	}
+}
+
+// decoder returns the cached decoder of t, compiling it on the first use
+func (d *argsDecoders) decoder(t reflect.Type) decodeFn {
+	d.mu.RLock()
+	fn, ok := d.decoders[t]
+	d.mu.RUnlock()
+	if ok {
+		return fn
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.compile(t)
+}
+
+// compile must be called with the lock held. Recursive types get a
+// trampoline while their decoder is being compiled.
+func (d *argsDecoders) compile(t reflect.Type) decodeFn {
+	if fn, ok := d.decoders[t]; ok {
+		return fn
+	}
+	var fn decodeFn
+	d.decoders[t] = func(param interface{}) (reflect.Value, error) {
+		return fn(param)
+	}
+	fn = d.build(t)
+	d.decoders[t] = fn
+	return fn
+}
+
+// build returns the decoder of t. Values of type t, e.g. parsed by a scalar,
+// are used as they are and null gives the zero value.
+func (d *argsDecoders) build(t reflect.Type) decodeFn {
+	decode := d.buildKind(t)
+	if t.Kind() != reflect.Ptr && t.Kind() != reflect.Interface {
+		decode = d.withUnmarshalers(t, decode)
+	}
+	return func(param interface{}) (reflect.Value, error) {
+		if param == nil {
+			return reflect.Zero(t), nil
+		}
+		if reflect.TypeOf(param) == t {
+			return reflect.ValueOf(param), nil
+		}
+		return decode(param)
+	}
+}
+
+// withUnmarshalers decodes strings with encoding.TextUnmarshaler and other
+// values with json.Unmarshaler when *t implements them
+func (d *argsDecoders) withUnmarshalers(t reflect.Type, decode decodeFn) decodeFn {
+	pt := reflect.PtrTo(t)
+	text := pt.Implements(textUnmarshalerType)
+	js := pt.Implements(jsonUnmarshalerType)
+	if !text && !js {
+		return decode
+	}
+	return func(param interface{}) (reflect.Value, error) {
+		ptr := reflect.New(t)
+		if s, ok := param.(string); ok && text {
+			if err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+				return reflect.Value{}, coercionErrorf(param, t, "%s", err)
+			}
+			return ptr.Elem(), nil
+		}
+		if js {
+			data, err := json.Marshal(param)
+			if err == nil {
+				err = ptr.Interface().(json.Unmarshaler).UnmarshalJSON(data)
+			}
+			if err != nil {
+				return reflect.Value{}, coercionErrorf(param, t, "%s", err)
+			}
+			return ptr.Elem(), nil
+		}
+		return decode(param)
+	}
+}
+
+func (d *argsDecoders) buildKind(t reflect.Type) decodeFn {
+	switch t.Kind() {
+	case reflect.Ptr:
+		elem := d.compile(t.Elem())
+		return func(param interface{}) (reflect.Value, error) {
+			v, err := elem(param)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			ptr := reflect.New(t.Elem())
+			ptr.Elem().Set(v)
+			return ptr, nil
+		}
+	case reflect.Struct:
+		return d.buildStruct(t)
+	case reflect.Slice:
+		elem := d.compile(t.Elem())
+		return func(param interface{}) (reflect.Value, error) {
+			items, ok := param.([]interface{})
+			if !ok {
+				// a single value is coerced to a list of one item
+				items = []interface{}{param}
+			}
+			slice := reflect.MakeSlice(t, len(items), len(items))
+			for i, item := range items {
+				v, err := elem(item)
+				if err != nil {
+					return reflect.Value{}, withPath(err, fmt.Sprintf("[%d]", i))
+				}
+				slice.Index(i).Set(v)
+			}
+			return slice, nil
+		}
+	case reflect.Array:
+		elem := d.compile(t.Elem())
+		return func(param interface{}) (reflect.Value, error) {
+			items, ok := param.([]interface{})
+			if !ok {
+				return reflect.Value{}, coercionErrorf(param, t, "expected a list")
+			}
+			if len(items) != t.Len() {
+				return reflect.Value{}, coercionErrorf(param, t, "expected %d items, got %d", t.Len(), len(items))
+			}
+			arr := reflect.New(t).Elem()
+			for i, item := range items {
+				v, err := elem(item)
+				if err != nil {
+					return reflect.Value{}, withPath(err, fmt.Sprintf("[%d]", i))
+				}
+				arr.Index(i).Set(v)
+			}
+			return arr, nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return func(param interface{}) (reflect.Value, error) {
+			return coerceNumber(param, t)
+		}
+	case reflect.Map:
+		return func(param interface{}) (reflect.Value, error) {
+			pv := reflect.ValueOf(param)
+			if pv.Type().AssignableTo(t) {
+				v := reflect.New(t).Elem()
+				v.Set(pv)
+				return v, nil
+			}
+			// JSON objects are converted to other map types through encoding/json
+			data, err := json.Marshal(param)
+			if err != nil {
+				return reflect.Value{}, coercionErrorf(param, t, "%s", err)
+			}
+			ptr := reflect.New(t)
+			if err := json.Unmarshal(data, ptr.Interface()); err != nil {
+				return reflect.Value{}, coercionErrorf(param, t, "%s", err)
+			}
+			return ptr.Elem(), nil
+		}
+	case reflect.String, reflect.Bool:
+		return func(param interface{}) (reflect.Value, error) {
+			pv := reflect.ValueOf(param)
+			if pv.Kind() != t.Kind() {
+				return reflect.Value{}, coercionErrorf(param, t, "expected a %s", t.Kind())
+			}
+			// enum values and plain strings are converted to the named Go type
+			return pv.Convert(t), nil
+		}
+	}
+	return func(param interface{}) (reflect.Value, error) {
+		pv := reflect.ValueOf(param)
+		if !pv.Type().AssignableTo(t) {
+			return reflect.Value{}, coercionErrorf(param, t, "unsupported value of type %s", pv.Type())
+		}
+		v := reflect.New(t).Elem()
+		v.Set(pv)
+		return v, nil
+	}
+}
+
+func (d *argsDecoders) buildStruct(t reflect.Type) decodeFn {
+	fields := make([]fieldDecoder, 0, t.NumField())
+	for _, f := range structFields(t) {
+		fields = append(fields, fieldDecoder{
+			name:   structFieldName(f, d.naming),
+			index:  f.Index,
+			decode: d.compile(f.Type),
+		})
+	}
+
+	return func(param interface{}) (reflect.Value, error) {
+		m, ok := param.(map[string]interface{})
+		if !ok {
+			return reflect.Value{}, coercionErrorf(param, t, "expected an input object")
+		}
+		val := reflect.New(t).Elem()
+		for _, f := range fields {
+			np, ok := m[f.name]
+			if !ok {
+				continue
+			}
+			fv, ok := settableFieldByIndex(val, f.index)
+			if !ok {
+				continue
+			}
+			v, err := f.decode(np)
+			if err != nil {
+				return reflect.Value{}, withPath(err, f.name)
+			}
+			fv.Set(v)
+		}
+		return val, nil
+	}
+}
+
+// coerceNumber converts between numeric kinds, it fails on overflows and on
+// fractional values for integer types
+func coerceNumber(param interface{}, t reflect.Type) (reflect.Value, error) {
+	pv := reflect.ValueOf(param)
+	v := reflect.New(t).Elem()
+
+	var f float64
+	switch pv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := pv.Int()
+		switch {
+		case isIntKind(t.Kind()):
+			if v.OverflowInt(i) {
+				return reflect.Value{}, coercionErrorf(param, t, "overflow")
+			}
+			v.SetInt(i)
+			return v, nil
+		case isUintKind(t.Kind()):
+			if i < 0 || v.OverflowUint(uint64(i)) {
+				return reflect.Value{}, coercionErrorf(param, t, "overflow")
+			}
+			v.SetUint(uint64(i))
+			return v, nil
+		}
+		f = float64(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := pv.Uint()
+		switch {
+		case isIntKind(t.Kind()):
+			if u > math.MaxInt64 || v.OverflowInt(int64(u)) {
+				return reflect.Value{}, coercionErrorf(param, t, "overflow")
+			}
+			v.SetInt(int64(u))
+			return v, nil
+		case isUintKind(t.Kind()):
+			if v.OverflowUint(u) {
+				return reflect.Value{}, coercionErrorf(param, t, "overflow")
+			}
+			v.SetUint(u)
+			return v, nil
+		}
+		f = float64(u)
+	case reflect.Float32, reflect.Float64:
+		f = pv.Float()
+	default:
+		return reflect.Value{}, coercionErrorf(param, t, "expected a number")
+	}
+
+	switch {
+	case isIntKind(t.Kind()):
+		// float64 cannot represent math.MaxInt64, so its bound is exclusive
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 || v.OverflowInt(int64(f)) {
+			return reflect.Value{}, coercionErrorf(param, t, "not an integer in range")
+		}
+		v.SetInt(int64(f))
+	case isUintKind(t.Kind()):
+		if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 || v.OverflowUint(uint64(f)) {
+			return reflect.Value{}, coercionErrorf(param, t, "not an integer in range")
+		}
+		v.SetUint(uint64(f))
+	default:
+		if v.OverflowFloat(f) {
+			return reflect.Value{}, coercionErrorf(param, t, "overflow")
+		}
+		v.SetFloat(f)
+	}
+	return v, nil
+}
+
+func isIntKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+// getDecoders returns the decoders of the args of the schema resolvers
+func (s *SchemaBuilder) getDecoders() *argsDecoders {
+	if s.decoders == nil {
+		s.decoders = newArgsDecoders(s.fieldNaming)
+	}
+	return s.decoders
+}
@@ -0,0 +1,54 @@
+package gqbuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BuildError is a schema mistake found while registering or building the
+// schema. Object and Field name the place of the mistake, Signature is the
+// offending Go type or signature and Expected describes what was expected.
+type BuildError struct {
+	Object    string
+	Field     string
+	Signature string
+	Expected  string
+	Message   string
+}
+
+func (e *BuildError) Error() string {
+	return e.Message
+}
+
+// BuildErrors aggregates all the mistakes found by Build, so they can be
+// fixed at once
+type BuildErrors []error
+
+func (e BuildErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// newBuildErrors returns nil when there are no errors
+func newBuildErrors(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return BuildErrors(errs)
+}
+
+// errorRecorder collects registration mistakes which are reported by Build
+type errorRecorder struct {
+	errors []error
+}
+
+func (r *errorRecorder) addError(err *BuildError) {
+	r.errors = append(r.errors, err)
+}
+
+func (r *errorRecorder) addErrorf(object string, field string, format string, args ...interface{}) {
+	r.addError(&BuildError{Object: object, Field: field, Message: fmt.Sprintf(format, args...)})
+}
@@ -0,0 +1,164 @@
+package gqbuilder
+
+import (
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"reflect"
+	"sort"
+)
+
+type InterfaceObject struct {
+	Name            string
+	Description     string
+	Type            interface{}
+	Interface       reflect.Type
+	Implementations []interface{}
+	errorRecorder
+}
+
+func (s *InterfaceObject) GetType() interface{} {
+	return s.Type
+}
+
+// Implementation registers a concrete struct which implements the Go interface
+func (s *InterfaceObject) Implementation(impl interface{}) {
+	t := reflect.TypeOf(impl)
+	if t == nil || t.Kind() != reflect.Struct {
+		s.addError(&BuildError{
+			Object:    s.Name,
+			Signature: fmt.Sprint(t),
+			Expected:  "struct",
+			Message:   fmt.Sprintf("Implementation of interface %s must be a struct, got %v", s.Name, t),
+		})
+		return
+	}
+	for _, i := range s.Implementations {
+		if reflect.TypeOf(i) == t {
+			s.addErrorf(s.Name, "", "Implementation %s of interface %s aready exists", t, s.Name)
+			return
+		}
+	}
+
+	s.Implementations = append(s.Implementations, impl)
+}
+
+// validate checks that there are implementations and that they satisfy the
+// Go interface, their fields are checked once built by validateInterfaceFields
+func (s *InterfaceObject) validate() []error {
+	if len(s.Implementations) == 0 {
+		return []error{&BuildError{Object: s.Name, Message: fmt.Sprintf("Interface %s has no implementations", s.Name)}}
+	}
+
+	errs := make([]error, 0)
+	for _, impl := range s.Implementations {
+		it := reflect.TypeOf(impl)
+		if !s.implementedBy(it) {
+			errs = append(errs, &BuildError{
+				Object:    s.Name,
+				Signature: it.String(),
+				Expected:  fmt.Sprintf("implementation of %s", s.Interface),
+				Message:   fmt.Sprintf("Type %s does not implement interface %s", it, s.Interface),
+			})
+		}
+	}
+	return errs
+}
+
+func (s *InterfaceObject) implementedBy(t reflect.Type) bool {
+	return t.Implements(s.Interface) || reflect.PtrTo(t).Implements(s.Interface)
+}
+
+// validateInterfaceFields checks that every implementation declares the
+// fields of its interfaces once the fields are built, so fields renamed with
+// a name tag or provided by a resolver count. The field of an implementation
+// may be non-null where the interface field is nullable.
+func (s *SchemaBuilder) validateInterfaceFields() []error {
+	errs := make([]error, 0)
+	for _, io := range s.interfaces {
+		iface, ok := s.builtIfaces[io.Name]
+		if !ok {
+			continue
+		}
+		fields := iface.Fields()
+		names := make([]string, 0, len(fields))
+		for n := range fields {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+
+		for _, impl := range io.Implementations {
+			it := reflect.TypeOf(impl)
+			obj, ok := s.builtOutputs[s.typeName(it)].(*graphql.Object)
+			if !ok || !io.implementedBy(it) {
+				// reported by validate
+				continue
+			}
+			implFields := obj.Fields()
+			for _, n := range names {
+				f := fields[n]
+				implField, ok := implFields[n]
+				if !ok {
+					errs = append(errs, &BuildError{
+						Object:   io.Name,
+						Field:    n,
+						Expected: f.Type.String(),
+						Message:  fmt.Sprintf("Interface %s field %s is not declared by %s", io.Name, n, it),
+					})
+					continue
+				}
+				if !isOutputSubType(implField.Type, f.Type) {
+					errs = append(errs, &BuildError{
+						Object:    io.Name,
+						Field:     n,
+						Signature: implField.Type.String(),
+						Expected:  f.Type.String(),
+						Message:   fmt.Sprintf("Interface %s field %s has type %s, but %s declares %s", io.Name, n, f.Type, it, implField.Type),
+					})
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// isOutputSubType reports whether a field of type t may implement an
+// interface field of type of. Non-null types are subtypes of their nullable
+// types, and objects are subtypes of their interfaces and unions.
+func isOutputSubType(t graphql.Type, of graphql.Type) bool {
+	if nn, ok := of.(*graphql.NonNull); ok {
+		tn, ok := t.(*graphql.NonNull)
+		return ok && isOutputSubType(tn.OfType, nn.OfType)
+	}
+	if tn, ok := t.(*graphql.NonNull); ok {
+		return isOutputSubType(tn.OfType, of)
+	}
+	if ol, ok := of.(*graphql.List); ok {
+		tl, ok := t.(*graphql.List)
+		return ok && isOutputSubType(tl.OfType, ol.OfType)
+	}
+	if _, ok := t.(*graphql.List); ok {
+		return false
+	}
+	if t.Name() == of.Name() {
+		return true
+	}
+	obj, ok := t.(*graphql.Object)
+	if !ok {
+		return false
+	}
+	switch v := of.(type) {
+	case *graphql.Interface:
+		for _, i := range obj.Interfaces() {
+			if i.Name() == v.Name() {
+				return true
+			}
+		}
+	case *graphql.Union:
+		for _, o := range v.Types() {
+			if o.Name() == obj.Name() {
+				return true
+			}
+		}
+	}
+	return false
+}
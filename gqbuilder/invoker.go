@@ -0,0 +1,117 @@
+package gqbuilder
+
+import (
+	"context"
+	"reflect"
+)
+
+// invoker calls a resolver with the param layout compiled once at Build, so
+// requests do not inspect the handler signature again
+type invoker struct {
+	fn       reflect.Value
+	numIn    int
+	parent   reflect.Type
+	argsPos  int
+	argsType reflect.Type
+	decode   decodeFn
+}
+
+// compileResolver compiles a field resolver, its signature is validated on
+// registration
+func (s *SchemaBuilder) compileResolver(fn interface{}, hasParent bool) *invoker {
+	kind, _ := classifyResolver(fn, hasParent)
+	return s.newInvoker(fn, kind)
+}
+
+// compileSubscription compiles a subscription handler, the chan is passed as
+// the second param
+func (s *SchemaBuilder) compileSubscription(fn interface{}) *invoker {
+	kind, _ := classifySubscription(fn)
+	return s.newInvoker(fn, kind)
+}
+
+func (s *SchemaBuilder) newInvoker(fn interface{}, kind handlerKind) *invoker {
+	iv := &invoker{fn: reflect.ValueOf(fn)}
+	ft := iv.fn.Type()
+	iv.numIn = ft.NumIn()
+
+	switch kind {
+	case handlerCtxArgs:
+		iv.argsPos = 1
+	case handlerCtxParent:
+		iv.parent = ft.In(1)
+	case handlerCtxParentArgs:
+		iv.parent = ft.In(1)
+		iv.argsPos = 2
+	case handlerSubscriptionArgs:
+		iv.argsPos = 2
+	}
+	if iv.argsPos > 0 {
+		iv.argsType = ft.In(iv.argsPos)
+		iv.decode = s.getDecoders().decoder(iv.argsType)
+	}
+	return iv
+}
+
+// params builds the handler params, it fails when the args cannot be decoded
+func (iv *invoker) params(ctx context.Context, source interface{}, args map[string]interface{}) ([]reflect.Value, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	in := make([]reflect.Value, iv.numIn)
+	in[0] = reflect.ValueOf(ctx)
+	if iv.parent != nil {
+		in[1] = getParentValue(source, iv.parent)
+	}
+	if iv.argsPos > 0 {
+		if len(args) > 0 {
+			v, err := iv.decode(args)
+			if err != nil {
+				return nil, err
+			}
+			in[iv.argsPos] = v
+		} else {
+			in[iv.argsPos] = reflect.Zero(iv.argsType)
+		}
+	}
+	return in, nil
+}
+
+// call runs a field resolver, nil results are returned as untyped nil and
+// args which cannot be decoded are returned as the field error
+func (iv *invoker) call(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+	in, err := iv.params(ctx, source, args)
+	if err != nil {
+		return nil, err
+	}
+	out := iv.fn.Call(in)
+
+	if !out[1].IsNil() {
+		err = out[1].Interface().(error)
+	}
+	if isNilValue(out[0]) {
+		return nil, err
+	}
+	return out[0].Interface(), err
+}
+
+// subscribe decodes the params of a subscription handler and returns the
+// func which runs it, the handler sends its results to c
+func (iv *invoker) subscribe(ctx context.Context, c chan interface{}, args map[string]interface{}) (func(), error) {
+	in, err := iv.params(ctx, nil, args)
+	if err != nil {
+		return nil, err
+	}
+	in[1] = reflect.ValueOf(c)
+	return func() { iv.fn.Call(in) }, nil
+}
+
+// isNilValue reports results which are returned as null, nil slices are kept
+// as they are returned as empty lists
+func isNilValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Interface, reflect.Func, reflect.Chan:
+		return v.IsNil()
+	}
+	return false
+}
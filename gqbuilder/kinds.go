@@ -0,0 +1,83 @@
+package gqbuilder
+
+import (
+	"github.com/graphql-go/graphql"
+	"reflect"
+)
+
+// kindTypes are the unnamed types of the basic kinds, named types such as
+// type Cents int64 or type Email string are mapped to the scalar of their kind
+var kindTypes = map[reflect.Kind]reflect.Type{
+	reflect.String:  reflect.TypeOf(""),
+	reflect.Bool:    reflect.TypeOf(false),
+	reflect.Float32: reflect.TypeOf(float32(0)),
+	reflect.Float64: reflect.TypeOf(float64(0)),
+	reflect.Int:     reflect.TypeOf(int(0)),
+	reflect.Int8:    reflect.TypeOf(int8(0)),
+	reflect.Int16:   reflect.TypeOf(int16(0)),
+	reflect.Int32:   reflect.TypeOf(int32(0)),
+	reflect.Int64:   reflect.TypeOf(int64(0)),
+	reflect.Uint:    reflect.TypeOf(uint(0)),
+	reflect.Uint8:   reflect.TypeOf(uint8(0)),
+	reflect.Uint16:  reflect.TypeOf(uint16(0)),
+	reflect.Uint32:  reflect.TypeOf(uint32(0)),
+	reflect.Uint64:  reflect.TypeOf(uint64(0)),
+}
+
+// kindScalar returns the scalar of the kind of a named type which has no
+// scalar of its own, enums registered for the type win
+func (s *SchemaBuilder) kindScalar(t reflect.Type) (*graphql.Scalar, bool) {
+	base, ok := kindTypes[t.Kind()]
+	if !ok || t == base {
+		return nil, false
+	}
+	if _, ok := s.enums[t]; ok {
+		return nil, false
+	}
+	return s.typeScalar(base)
+}
+
+// kindConverter returns a func which converts values of t holding named
+// types mapped by kindScalar to the unnamed types of their kinds, the
+// built-in scalars such as Int or String only serialize unnamed types. It returns nil
+// when the values of t need no conversion.
+func (s *SchemaBuilder) kindConverter(t reflect.Type) func(reflect.Value) reflect.Value {
+	switch t.Kind() {
+	case reflect.Ptr:
+		elem := s.kindConverter(t.Elem())
+		if elem == nil {
+			return nil
+		}
+		return func(v reflect.Value) reflect.Value {
+			if v.IsNil() {
+				return v
+			}
+			return elem(v.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		elem := s.kindConverter(t.Elem())
+		if elem == nil {
+			return nil
+		}
+		return func(v reflect.Value) reflect.Value {
+			if v.Kind() == reflect.Slice && v.IsNil() {
+				return v
+			}
+			items := make([]interface{}, v.Len())
+			for i := range items {
+				items[i] = elem(v.Index(i)).Interface()
+			}
+			return reflect.ValueOf(items)
+		}
+	}
+	if _, ok := s.typeScalar(t); ok {
+		return nil
+	}
+	if _, ok := s.kindScalar(t); !ok {
+		return nil
+	}
+	base := kindTypes[t.Kind()]
+	return func(v reflect.Value) reflect.Value {
+		return v.Convert(base)
+	}
+}
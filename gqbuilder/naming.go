@@ -0,0 +1,158 @@
+package gqbuilder
+
+import (
+	"fmt"
+	"github.com/iancoleman/strcase"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// TypeNamingFn derives the GraphQL type name of a Go type
+type TypeNamingFn func(t reflect.Type) string
+
+// DefaultTypeNaming names types after the Go type name
+func DefaultTypeNaming(t reflect.Type) string {
+	return getKey(t)
+}
+
+// PackageTypeNaming prefixes the Go type name with its package name, so types
+// with the same name from different packages do not collide
+func PackageTypeNaming(t reflect.Type) string {
+	if t.Name() == "" || t.PkgPath() == "" {
+		return getKey(t)
+	}
+	return strcase.ToCamel(path.Base(t.PkgPath())) + t.Name()
+}
+
+// SetTypeNaming sets the strategy used for types without an explicit name
+func (s *SchemaBuilder) SetTypeNaming(fn TypeNamingFn) {
+	s.typeNaming = fn
+}
+
+// SetTypeName overrides the GraphQL type name of the type of obj
+func (s *SchemaBuilder) SetTypeName(obj interface{}, name string) {
+	t := reflect.TypeOf(obj)
+	if s.typeNames == nil {
+		s.typeNames = make(map[reflect.Type]string)
+	}
+	if n, ok := s.typeNames[t]; ok && n != name {
+		s.addErrorf(name, "", "Type %s aready has name %s", t, n)
+		return
+	}
+	s.typeNames[t] = name
+}
+
+// typeName returns the GraphQL name of a Go type. Names set with SetTypeName
+// or Object win over the typeName tag of a blank field, which wins over the
+// naming strategy.
+func (s *SchemaBuilder) typeName(t reflect.Type) string {
+	if n, ok := s.typeNames[t]; ok {
+		return n
+	}
+	if n, ok := findTypeNameTag(t); ok {
+		return n
+	}
+	if s.typeNaming != nil {
+		return s.typeNaming(t)
+	}
+	return DefaultTypeNaming(t)
+}
+
+func (s *SchemaBuilder) typeNameWithHash(t reflect.Type, ig map[string]string) string {
+	return fmt.Sprintf("%s%s", s.typeName(t), hashFromArr(ig))
+}
+
+// findTypeNameTag looks up the name declared on a blank field of a struct,
+// e.g. _ struct{} `gomer:"typeName:TicketType"`
+func findTypeNameTag(t reflect.Type) (string, bool) {
+	if t.Kind() != reflect.Struct {
+		return "", false
+	}
+	if f, ok := t.FieldByName("_"); ok {
+		return findGomerTags(f).ParamExist("typeName")
+	}
+	return "", false
+}
+
+// registerTypeName records the Go type which is built under a GraphQL name
+func (s *SchemaBuilder) registerTypeName(name string, t reflect.Type) {
+	if s.typeNameOwners == nil {
+		s.typeNameOwners = make(map[string][]reflect.Type)
+	}
+	for _, o := range s.typeNameOwners[name] {
+		if o == t {
+			return
+		}
+	}
+	s.typeNameOwners[name] = append(s.typeNameOwners[name], t)
+}
+
+// validateTypeNames reports every GraphQL name used by several Go types
+func (s *SchemaBuilder) validateTypeNames() []error {
+	names := make([]string, 0)
+	for name, owners := range s.typeNameOwners {
+		if len(owners) > 1 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	errs := make([]error, 0, len(names))
+	for _, name := range names {
+		owners := s.typeNameOwners[name]
+		types := make([]string, 0, len(owners))
+		for _, o := range owners {
+			types = append(types, fmt.Sprintf("%s.%s", o.PkgPath(), o.Name()))
+		}
+		sort.Strings(types)
+		errs = append(errs, &BuildError{
+			Object:    name,
+			Signature: strings.Join(types, ", "),
+			Expected:  "one Go type per GraphQL type name",
+			Message:   fmt.Sprintf("GraphQL type name %s is used by several Go types: %s", name, strings.Join(types, ", ")),
+		})
+	}
+	return errs
+}
+
+// FieldNamingFn derives the GraphQL name of a struct field, the name param of
+// the gomer tag wins over it
+type FieldNamingFn func(f reflect.StructField) string
+
+// SnakeFieldNaming names fields in snake_case, it is the default strategy
+func SnakeFieldNaming(f reflect.StructField) string {
+	return getFieldName(f.Name)
+}
+
+// CamelFieldNaming names fields in lowerCamelCase
+func CamelFieldNaming(f reflect.StructField) string {
+	return strcase.ToLowerCamel(f.Name)
+}
+
+// JSONFieldNaming uses the name of the json tag and falls back to snake_case
+// for fields without one
+func JSONFieldNaming(f reflect.StructField) string {
+	n := strings.Split(f.Tag.Get("json"), ",")[0]
+	if n == "" || n == "-" {
+		return SnakeFieldNaming(f)
+	}
+	return n
+}
+
+// SetFieldNaming sets the strategy used to name the fields of outputs, inputs
+// and args, args are decoded with the same names
+func (s *SchemaBuilder) SetFieldNaming(fn FieldNamingFn) {
+	s.fieldNaming = fn
+	// decoders compiled with the previous naming are dropped
+	s.decoders = nil
+}
+
+func (s *SchemaBuilder) fieldName(f reflect.StructField) string {
+	return structFieldName(f, s.fieldNaming)
+}
+
+func isBlankField(f reflect.StructField) bool {
+	return f.Name == "_"
+}
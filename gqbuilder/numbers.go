@@ -0,0 +1,178 @@
+package gqbuilder
+
+import (
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// Scalars of the Go integer kinds which have no GraphQL counterpart, values
+// out of the range of the kind are rejected on parse
+var (
+	Int64Scalar  = NewIntScalar("int64", reflect.TypeOf(int64(0)), false)
+	Int8Scalar   = NewIntScalar("int8", reflect.TypeOf(int8(0)), false)
+	Int16Scalar  = NewIntScalar("int16", reflect.TypeOf(int16(0)), false)
+	UintScalar   = NewIntScalar("uint", reflect.TypeOf(uint(0)), false)
+	Uint8Scalar  = NewIntScalar("uint8", reflect.TypeOf(uint8(0)), false)
+	Uint16Scalar = NewIntScalar("uint16", reflect.TypeOf(uint16(0)), false)
+	Uint32Scalar = NewIntScalar("uint32", reflect.TypeOf(uint32(0)), false)
+	Uint64Scalar = NewIntScalar("uint64", reflect.TypeOf(uint64(0)), false)
+)
+
+// Int64StringScalar and Uint64StringScalar serialize 64-bit integers as
+// strings, which JavaScript clients read without losing precision. They are
+// used instead of Int64Scalar and Uint64Scalar with SetInt64AsString, and are
+// named apart from them so both may appear in a schema.
+var (
+	Int64StringScalar  = NewIntScalar("int64_string", reflect.TypeOf(int64(0)), true)
+	Uint64StringScalar = NewIntScalar("uint64_string", reflect.TypeOf(uint64(0)), true)
+)
+
+// numberScalars maps the Go numeric kinds to their scalars, int32 fits the
+// GraphQL Int
+var numberScalars = map[reflect.Type]*graphql.Scalar{
+	reflect.TypeOf(int8(0)):   Int8Scalar,
+	reflect.TypeOf(int16(0)):  Int16Scalar,
+	reflect.TypeOf(int32(0)):  graphql.Int,
+	reflect.TypeOf(uint(0)):   UintScalar,
+	reflect.TypeOf(uint8(0)):  Uint8Scalar,
+	reflect.TypeOf(uint16(0)): Uint16Scalar,
+	reflect.TypeOf(uint32(0)): Uint32Scalar,
+	reflect.TypeOf(uint64(0)): Uint64Scalar,
+}
+
+var stringNumberScalars = map[reflect.Type]*graphql.Scalar{
+	reflect.TypeOf(int64(0)):  Int64StringScalar,
+	reflect.TypeOf(uint64(0)): Uint64StringScalar,
+}
+
+// SetInt64AsString serializes int64 and uint64 fields as strings, both
+// numbers and strings are accepted as input
+func (s *SchemaBuilder) SetInt64AsString(asString bool) {
+	s.int64AsString = asString
+}
+
+// NewIntScalar builds a scalar for the integer type t. Values are parsed from
+// numbers and strings and must fit t, they are serialized as numbers or, with
+// asString, as strings.
+func NewIntScalar(name string, t reflect.Type, asString bool) *graphql.Scalar {
+	kind := t.Kind()
+	signed := isIntKind(kind)
+	bits := t.Bits()
+
+	toValue := func(i int64, u uint64, negative bool) (interface{}, error) {
+		v := reflect.New(t).Elem()
+		if signed {
+			if (!negative && u > math.MaxInt64) || v.OverflowInt(i) {
+				return nil, fmt.Errorf("value is out of the %s range", name)
+			}
+			v.SetInt(i)
+		} else {
+			if negative || v.OverflowUint(u) {
+				return nil, fmt.Errorf("value is out of the %s range", name)
+			}
+			v.SetUint(u)
+		}
+		return v.Interface(), nil
+	}
+	parseString := func(value string) (interface{}, error) {
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return toValue(i, uint64(i), i < 0)
+		}
+		u, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", value)
+		}
+		return toValue(int64(u), u, false)
+	}
+
+	return NewScalar(ScalarConfig{
+		Name:        name,
+		Description: fmt.Sprintf("%d-bit integer", bits),
+		Serialize: func(value interface{}) (interface{}, error) {
+			v := reflect.ValueOf(value)
+			if v.Kind() == reflect.Ptr && !v.IsNil() {
+				v = v.Elem()
+			}
+			var i int64
+			var u uint64
+			switch {
+			case isIntKind(v.Kind()):
+				i = v.Int()
+				if signed {
+					return formatInt(i, bits, asString), nil
+				}
+				if i < 0 {
+					return nil, fmt.Errorf("value is out of the %s range", name)
+				}
+				u = uint64(i)
+			case isUintKind(v.Kind()):
+				u = v.Uint()
+				if signed {
+					if u > math.MaxInt64 {
+						return nil, fmt.Errorf("value is out of the %s range", name)
+					}
+					return formatInt(int64(u), bits, asString), nil
+				}
+			default:
+				return nil, unexpectedValueError(name, value)
+			}
+			return formatUint(u, bits, asString), nil
+		},
+		ParseValue: func(value interface{}) (interface{}, error) {
+			v := reflect.ValueOf(value)
+			switch {
+			case isIntKind(v.Kind()):
+				i := v.Int()
+				return toValue(i, uint64(i), i < 0)
+			case isUintKind(v.Kind()):
+				u := v.Uint()
+				return toValue(int64(u), u, false)
+			case v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64:
+				f := v.Float()
+				if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxUint64 {
+					return nil, fmt.Errorf("%v is not an integer", f)
+				}
+				if f < 0 {
+					return toValue(int64(f), 0, true)
+				}
+				return toValue(int64(f), uint64(f), false)
+			case v.Kind() == reflect.String:
+				return parseString(v.String())
+			}
+			return nil, unexpectedValueError(name, value)
+		},
+		ParseLiteral: func(valueAST ast.Value) (interface{}, error) {
+			switch v := valueAST.(type) {
+			case *ast.IntValue:
+				return parseString(v.Value)
+			case *ast.StringValue:
+				return parseString(v.Value)
+			}
+			return nil, unexpectedLiteralError(name, valueAST)
+		},
+	})
+}
+
+func formatInt(i int64, bits int, asString bool) interface{} {
+	switch {
+	case asString:
+		return strconv.FormatInt(i, 10)
+	case bits == 64:
+		return i
+	}
+	return int(i)
+}
+
+func formatUint(u uint64, bits int, asString bool) interface{} {
+	switch {
+	case asString:
+		return strconv.FormatUint(u, 10)
+	case bits == 64:
+		return u
+	}
+	return int(u)
+}
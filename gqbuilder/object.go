@@ -1,7 +1,8 @@
 package gqbuilder
 
 import (
-	log "github.com/sirupsen/logrus"
+	"fmt"
+	"reflect"
 )
 
 type Object struct {
@@ -11,32 +12,118 @@ type Object struct {
 	Resolver    interface{}
 	Args        interface{}
 	Methods     map[string]*Method
+	errorRecorder
 }
 
 func (s *Object) GetType() interface{} {
 	return s.Type
 }
 
-func (s *Object) FieldResolver(name string, handler interface{}) {
-	s.checkMethods(name)
+// isRoot reports whether the object is the query or the mutation, root
+// field resolvers have no parent param
+func (s *Object) isRoot() bool {
+	switch s.Type.(type) {
+	case query, mutation:
+		return true
+	}
+	return false
+}
 
-	s.Methods[name] = &Method{
+// FieldResolver registers a field resolver and returns its handle, which
+// configures the generated field, e.g. FieldResolver(...).Description("...")
+func (s *Object) FieldResolver(name string, handler interface{}) *Method {
+	m := &Method{
 		Name: name,
 		Fn:   handler,
 	}
+	if !s.checkMethods(name) || !s.checkSignature(name, handler) {
+		return m
+	}
+	s.Methods[name] = m
+	return m
+}
+
+// checkSignature records an error when the handler does not match one of the
+// supported resolver signatures
+func (s *Object) checkSignature(name string, handler interface{}) bool {
+	if _, err := classifyResolver(handler, !s.isRoot()); err != nil {
+		expected := objectResolverSignature
+		if s.isRoot() {
+			expected = rootResolverSignature
+		}
+		s.addError(&BuildError{
+			Object:    s.Name,
+			Field:     name,
+			Signature: fmt.Sprint(reflect.TypeOf(handler)),
+			Expected:  expected,
+			Message:   fmt.Sprintf("Resolver %s of object %s has invalid signature %v: %s, expected %s", name, s.Name, reflect.TypeOf(handler), err, expected),
+		})
+		return false
+	}
+	return true
 }
 
-func (s *Object) checkMethods(name string) {
+func (s *Object) checkMethods(name string) bool {
 	if s.Methods == nil {
 		s.Methods = make(map[string]*Method)
 	}
 
 	if _, ok := s.Methods[name]; ok {
-		log.Panicf("Func with name %s aready exists", name)
+		s.addErrorf(s.Name, name, "Func with name %s aready exists", name)
+		return false
 	}
+	return true
 }
 
 type Method struct {
+	FieldConfig
 	Name string
 	Fn   interface{}
 }
+
+// FieldConfig holds the metadata of a generated field and of its args
+type FieldConfig struct {
+	description       string
+	deprecationReason string
+	args              map[string]*argConfig
+}
+
+type argConfig struct {
+	description  string
+	defaultValue interface{}
+}
+
+// Description sets the description of the field
+func (fc *FieldConfig) Description(description string) *FieldConfig {
+	fc.description = description
+	return fc
+}
+
+// Deprecated marks the field as deprecated with the given reason
+func (fc *FieldConfig) Deprecated(reason string) *FieldConfig {
+	fc.deprecationReason = reason
+	return fc
+}
+
+// ArgDescription sets the description of the arg with the given GraphQL name
+func (fc *FieldConfig) ArgDescription(name string, description string) *FieldConfig {
+	fc.getArg(name).description = description
+	return fc
+}
+
+// ArgDefault sets the default value of the arg with the given GraphQL name,
+// an arg with a default value may be omitted by clients
+func (fc *FieldConfig) ArgDefault(name string, value interface{}) *FieldConfig {
+	fc.getArg(name).defaultValue = value
+	return fc
+}
+
+func (fc *FieldConfig) getArg(name string) *argConfig {
+	if fc.args == nil {
+		fc.args = make(map[string]*argConfig)
+	}
+	if _, ok := fc.args[name]; !ok {
+		fc.args[name] = &argConfig{}
+	}
+	return fc.args[name]
+}
@@ -0,0 +1,242 @@
+package gqbuilder
+
+import (
+	"context"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"strconv"
+	"strings"
+)
+
+// FieldState tells whether the client omitted an input field, sent it as
+// null or sent a value
+type FieldState int
+
+const (
+	FieldAbsent FieldState = iota
+	FieldNull
+	FieldSet
+)
+
+func (s FieldState) String() string {
+	switch s {
+	case FieldNull:
+		return "null"
+	case FieldSet:
+		return "set"
+	}
+	return "absent"
+}
+
+// InputFields records the args and input fields sent by the client, through
+// nested input objects and lists. Decoded args cannot tell an omitted field
+// from a null one, so patch-style resolvers check them here, e.g.
+// InputFieldsFromContext(ctx).State("input.title"). Defaults are not recorded
+// as sent. A nil *InputFields reports every field as absent.
+type InputFields struct {
+	state  FieldState
+	fields map[string]*InputFields
+	items  []*InputFields
+}
+
+// State returns the state of the field at path, path holds GraphQL names
+// separated by dots and list indexes, e.g. input.tags[1].title
+func (f *InputFields) State(path string) FieldState {
+	if f = f.Get(path); f == nil {
+		return FieldAbsent
+	}
+	return f.state
+}
+
+// Has reports whether the field at path was sent, as null or as a value
+func (f *InputFields) Has(path string) bool {
+	return f.State(path) != FieldAbsent
+}
+
+// IsNull reports whether the field at path was sent as null
+func (f *InputFields) IsNull(path string) bool {
+	return f.State(path) == FieldNull
+}
+
+// Get returns the fields of the input object or of the list at path, it
+// returns nil when nothing was sent there
+func (f *InputFields) Get(path string) *InputFields {
+	for _, segment := range splitInputPath(path) {
+		if i, err := strconv.Atoi(segment); err == nil {
+			f = f.Item(i)
+		} else {
+			f = f.Field(segment)
+		}
+	}
+	return f
+}
+
+// Field returns the input field with the given GraphQL name
+func (f *InputFields) Field(name string) *InputFields {
+	if f == nil {
+		return nil
+	}
+	return f.fields[name]
+}
+
+// Item returns the list item with the given index
+func (f *InputFields) Item(i int) *InputFields {
+	if f == nil || i < 0 || i >= len(f.items) {
+		return nil
+	}
+	return f.items[i]
+}
+
+// Len returns the number of the list items
+func (f *InputFields) Len() int {
+	if f == nil {
+		return 0
+	}
+	return len(f.items)
+}
+
+// Names returns the GraphQL names of the sent input fields
+func (f *InputFields) Names() []string {
+	if f == nil {
+		return nil
+	}
+	names := make([]string, 0, len(f.fields))
+	for name := range f.fields {
+		names = append(names, name)
+	}
+	return names
+}
+
+func splitInputPath(path string) []string {
+	path = strings.NewReplacer("[", ".", "]", "").Replace(path)
+	var segments []string
+	for _, s := range strings.Split(path, ".") {
+		if s != "" {
+			segments = append(segments, s)
+		}
+	}
+	return segments
+}
+
+// InputFieldsFromContext returns the args sent for the field being resolved,
+// it returns nil outside of resolvers
+func InputFieldsFromContext(ctx context.Context) *InputFields {
+	ls, ok := ctx.Value(selectionKey{}).(*lazySelection)
+	if !ok {
+		return nil
+	}
+	return newInputFields(ctx, ls.params)
+}
+
+// newInputFields reads the args from the query document, variables are read
+// from the raw request variables kept by variablesExtension. Without them, as
+// with graphql.Execute, the coerced variables are used, which drop the null
+// fields of input objects.
+func newInputFields(ctx context.Context, p graphql.ResolveParams) *InputFields {
+	variables, raw := ctx.Value(variablesKey{}).(map[string]interface{})
+	if !raw {
+		variables = p.Info.VariableValues
+	}
+
+	root := &InputFields{state: FieldSet, fields: map[string]*InputFields{}}
+	if len(p.Info.FieldASTs) == 0 {
+		return root
+	}
+	for _, arg := range p.Info.FieldASTs[0].Arguments {
+		if f := inputFieldsFromAST(arg.Value, variables); f != nil {
+			root.fields[arg.Name.Value] = f
+		}
+	}
+	return root
+}
+
+func inputFieldsFromAST(value ast.Value, variables map[string]interface{}) *InputFields {
+	switch v := value.(type) {
+	case *ast.Variable:
+		vv, ok := variables[v.Name.Value]
+		if !ok {
+			return nil
+		}
+		return inputFieldsFromValue(vv)
+	case *ast.ObjectValue:
+		f := &InputFields{state: FieldSet, fields: make(map[string]*InputFields, len(v.Fields))}
+		for _, of := range v.Fields {
+			if item := inputFieldsFromAST(of.Value, variables); item != nil {
+				f.fields[of.Name.Value] = item
+			}
+		}
+		return f
+	case *ast.ListValue:
+		f := &InputFields{state: FieldSet, items: make([]*InputFields, len(v.Values))}
+		for i, item := range v.Values {
+			f.items[i] = inputFieldsFromAST(item, variables)
+		}
+		return f
+	}
+	return &InputFields{state: FieldSet}
+}
+
+func inputFieldsFromValue(value interface{}) *InputFields {
+	switch v := value.(type) {
+	case nil:
+		return &InputFields{state: FieldNull}
+	case map[string]interface{}:
+		f := &InputFields{state: FieldSet, fields: make(map[string]*InputFields, len(v))}
+		for name, item := range v {
+			f.fields[name] = inputFieldsFromValue(item)
+		}
+		return f
+	case []interface{}:
+		f := &InputFields{state: FieldSet, items: make([]*InputFields, len(v))}
+		for i, item := range v {
+			f.items[i] = inputFieldsFromValue(item)
+		}
+		return f
+	}
+	return &InputFields{state: FieldSet}
+}
+
+type variablesKey struct{}
+
+// variablesExtension keeps the raw request variables in the context, graphql-go
+// passes only coerced variables to resolvers and those drop null fields
+type variablesExtension struct{}
+
+func (variablesExtension) Init(ctx context.Context, p *graphql.Params) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if p.VariableValues == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, variablesKey{}, p.VariableValues)
+}
+
+func (variablesExtension) Name() string {
+	return "gomer-variables"
+}
+
+func (variablesExtension) ParseDidStart(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
+	return ctx, func(error) {}
+}
+
+func (variablesExtension) ValidationDidStart(ctx context.Context) (context.Context, graphql.ValidationFinishFunc) {
+	return ctx, func([]gqlerrors.FormattedError) {}
+}
+
+func (variablesExtension) ExecutionDidStart(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+	return ctx, func(*graphql.Result) {}
+}
+
+func (variablesExtension) ResolveFieldDidStart(ctx context.Context, _ *graphql.ResolveInfo) (context.Context, graphql.ResolveFieldFinishFunc) {
+	return ctx, func(interface{}, error) {}
+}
+
+func (variablesExtension) HasResult() bool {
+	return false
+}
+
+func (variablesExtension) GetResult(context.Context) interface{} {
+	return nil
+}
@@ -0,0 +1,137 @@
+package gqbuilder
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SkippedField is a struct field left out of the schema because it has no
+// GraphQL mapping
+type SkippedField struct {
+	// Path is the Go type path of the field, e.g. models.Ticket.Events
+	Path   string
+	Type   reflect.Type
+	Reason string
+}
+
+// BuildReport lists the problems found while building the schema
+type BuildReport struct {
+	SkippedFields []SkippedField
+}
+
+// SetStrict makes Build fail on fields without a GraphQL mapping instead of
+// skipping them
+func (s *SchemaBuilder) SetStrict(strict bool) {
+	s.strict = strict
+}
+
+// schemaFields returns the fields of t which are mapped to the schema as
+// input or output fields, the other ones are recorded in the build report
+// under path
+func (s *SchemaBuilder) schemaFields(t reflect.Type, path string, input bool) []reflect.StructField {
+	fields := make([]reflect.StructField, 0, t.NumField())
+	for _, f := range structFields(t) {
+		if reason := s.unsupportedReason(t, f, input); reason != "" {
+			s.skipField(fmt.Sprintf("%s.%s", path, f.Name), f.Type, reason)
+			continue
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// supportedFields returns the fields of t which are mapped to the schema
+// without reporting the other ones
+func (s *SchemaBuilder) supportedFields(t reflect.Type, input bool) []reflect.StructField {
+	fields := make([]reflect.StructField, 0, t.NumField())
+	for _, f := range structFields(t) {
+		if s.unsupportedReason(t, f, input) == "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// unsupportedReason explains why a field cannot be mapped to the schema as
+// an input or output field, it returns an empty string for supported fields
+func (s *SchemaBuilder) unsupportedReason(owner reflect.Type, f reflect.StructField, input bool) string {
+	if f.PkgPath != "" {
+		return "unexported field"
+	}
+	for i := 1; i < len(f.Index); i++ {
+		ef := owner.FieldByIndex(f.Index[:i])
+		if ef.PkgPath != "" && ef.Type.Kind() == reflect.Ptr {
+			return fmt.Sprintf("promoted through unexported embedded pointer %s", ef.Name)
+		}
+	}
+
+	at := s.getActualTypeRecursive(f.Type)
+	if _, ok := s.isLeaf(at); ok {
+		return ""
+	}
+	if s.isAbstract(at) {
+		if input {
+			return fmt.Sprintf("interface or union type %s cannot be used in inputs", at)
+		}
+		return ""
+	}
+	switch at.Kind() {
+	case reflect.Struct:
+		return ""
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return fmt.Sprintf("%s kind is not supported", at.Kind())
+	}
+	return fmt.Sprintf("type %s has no GraphQL mapping", at)
+}
+
+// noFieldsError reports a type left without fields, which GraphQL rejects
+func noFieldsError(kind string, name string, t reflect.Type) *BuildError {
+	return &BuildError{
+		Object:    name,
+		Signature: t.String(),
+		Expected:  "at least one field with a GraphQL mapping",
+		Message:   fmt.Sprintf("%s %s has no fields with a GraphQL mapping", kind, name),
+	}
+}
+
+func (s *SchemaBuilder) skipField(path string, t reflect.Type, reason string) {
+	if s.skippedFields == nil {
+		s.skippedFields = make(map[string]SkippedField)
+	}
+	s.skippedFields[path] = SkippedField{Path: path, Type: t, Reason: reason}
+}
+
+// report returns the build report sorted by field path
+func (s *SchemaBuilder) report() *BuildReport {
+	r := &BuildReport{SkippedFields: make([]SkippedField, 0, len(s.skippedFields))}
+	for _, f := range s.skippedFields {
+		r.SkippedFields = append(r.SkippedFields, f)
+	}
+	sort.Slice(r.SkippedFields, func(i, j int) bool {
+		return r.SkippedFields[i].Path < r.SkippedFields[j].Path
+	})
+	return r
+}
+
+// validateSkippedFields fails the build on skipped fields in strict mode
+func (s *SchemaBuilder) validateSkippedFields() []error {
+	if !s.strict {
+		return nil
+	}
+	errs := make([]error, 0, len(s.skippedFields))
+	for _, f := range s.report().SkippedFields {
+		object, field := f.Path, ""
+		if i := strings.LastIndex(f.Path, "."); i >= 0 {
+			object, field = f.Path[:i], f.Path[i+1:]
+		}
+		errs = append(errs, &BuildError{
+			Object:    object,
+			Field:     field,
+			Signature: f.Type.String(),
+			Message:   fmt.Sprintf("Field %s of type %s is skipped: %s", f.Path, f.Type, f.Reason),
+		})
+	}
+	return errs
+}
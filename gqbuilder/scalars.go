@@ -1,158 +1,307 @@
 package gqbuilder
 
 import (
+	"encoding"
+	"encoding/json"
+	"fmt"
 	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/graphql/language/ast"
 	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
+	"reflect"
 	"strconv"
 )
 
-//var JsonField = graphql.NewScalar(graphql.ScalarConfig{
-//	Name:        "JSON",
-//	Description: ``,
-//	Serialize: func(value interface{}) interface{} {
-//		switch value := value.(type) {
-//		case *datatypes.JSON:
-//			return *value
-//		case datatypes.JSON:
-//			return value
-//		default:
-//			panic("Value is not JSON")
-//		}
-//
-//		return nil
-//	},
-//	// parseValue: gets invoked to parse client input that was passed through variables.
-//	// value is plain type
-//	ParseValue: func(value interface{}) interface{} {
-//		switch value := value.(type) {
-//		case *datatypes.JSON:
-//			return *value
-//		case datatypes.JSON:
-//			return value
-//		default:
-//			panic("Value is not JSON")
-//		}
-//
-//		return nil
-//	},
-//	// parseLiteral: gets invoked to parse client input that was passed inline in the query.
-//	// value is ast.Value
-//	ParseLiteral: func(valueAST ast.Value) interface{} {
-//		switch valueAST := valueAST.(type) {
-//		case *ast.StringValue:
-//			res := datatypes.JSON{}
-//			bytes := []byte(valueAST.Value)
-//			err := res.UnmarshalJSON(bytes)
-//			if err != nil {
-//				panic(err)
-//			}
-//
-//			return res
-//		}
-//		return nil
-//	},
-//})
-
-var Int64Scalar = graphql.NewScalar(graphql.ScalarConfig{
-	Name:        "int64",
-	Description: ``,
-	Serialize: func(value interface{}) interface{} {
-		switch value := value.(type) {
-		case *int64:
-			return *value
-		case int64:
-			return value
-		default:
-			log.Errorf("Value is not int64, actial type is %v", value)
+// JSONScalar holds any JSON value, it maps map kinds, interface{} and
+// json.RawMessage. Literals are parsed from objects, lists and plain values.
+var JSONScalar = NewScalar(ScalarConfig{
+	Name:        "JSON",
+	Description: "Any JSON value",
+	Serialize: func(value interface{}) (interface{}, error) {
+		switch v := value.(type) {
+		case nil, string, bool, int, int64, float64, map[string]interface{}, []interface{}:
+			return v, nil
+		case json.RawMessage:
+			return decodeJSON(v)
+		case *json.RawMessage:
+			return decodeJSON(*v)
 		}
-
-		return nil
+		// other values are serialized as encoding/json would write them
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		return decodeJSON(data)
+	},
+	ParseValue: func(value interface{}) (interface{}, error) {
+		return value, nil
 	},
-	// parseValue: gets invoked to parse client input that was passed through variables.
-	// value is plain type
-	ParseValue: func(value interface{}) interface{} {
-		switch value := value.(type) {
-		case int64:
-			return value
-		case float64:
-			return int64(value)
-		case string:
-			i, err := strconv.ParseInt(value, 10, 64)
+	ParseLiteral: func(valueAST ast.Value) (interface{}, error) {
+		return literalValue(valueAST)
+	},
+})
+
+func decodeJSON(data []byte) (interface{}, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// isJSONKind reports whether t holds arbitrary JSON, map kinds and the empty
+// interface map to JSONScalar
+func isJSONKind(t reflect.Type) bool {
+	return t.Kind() == reflect.Map || (t.Kind() == reflect.Interface && t.NumMethod() == 0)
+}
+
+// ScalarConfig configures a scalar built by NewScalar. Its funcs return
+// errors instead of logging them: values which cannot be parsed make the
+// query invalid with the path of the argument, values which cannot be
+// serialized fail the field.
+type ScalarConfig struct {
+	Name         string
+	Description  string
+	Serialize    func(value interface{}) (interface{}, error)
+	ParseValue   func(value interface{}) (interface{}, error)
+	ParseLiteral func(valueAST ast.Value) (interface{}, error)
+}
+
+// ScalarError is the error of a field whose value cannot be serialized
+type ScalarError struct {
+	Scalar string
+	Value  interface{}
+	Err    error
+}
+
+func (e *ScalarError) Error() string {
+	return fmt.Sprintf("Cannot serialize %v as %s: %s", e.Value, e.Scalar, e.Err)
+}
+
+func (e *ScalarError) Unwrap() error {
+	return e.Err
+}
+
+// NewScalar builds a scalar following the error convention of ScalarConfig
+func NewScalar(config ScalarConfig) *graphql.Scalar {
+	return graphql.NewScalar(graphql.ScalarConfig{
+		Name:        config.Name,
+		Description: config.Description,
+		Serialize: func(value interface{}) interface{} {
+			out, err := config.Serialize(value)
 			if err != nil {
-				log.Errorf("Cannot convert %v to int64, %s", value, err)
+				// graphql-go turns panics of serializers into errors of the field
+				panic(&ScalarError{Scalar: config.Name, Value: value, Err: err})
 			}
-			return i
-		default:
-			log.Errorf("Value is not int64, actial type is %v", value)
+			return out
+		},
+		ParseValue: func(value interface{}) interface{} {
+			out, err := config.ParseValue(value)
+			if err != nil {
+				// a nil result is reported by graphql-go as an invalid variable value
+				log.Debugf("Cannot parse %v as %s, %s", value, config.Name, err)
+				return nil
+			}
+			return out
+		},
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			out, err := config.ParseLiteral(valueAST)
+			if err != nil {
+				// a nil result is reported by graphql-go as an invalid argument value
+				log.Debugf("Cannot parse %s literal as %s, %s", valueAST.GetKind(), config.Name, err)
+				return nil
+			}
+			return out
+		},
+	})
+}
+
+func unexpectedValueError(name string, value interface{}) error {
+	return fmt.Errorf("expected %s, got %T", name, value)
+}
+
+func unexpectedLiteralError(name string, valueAST ast.Value) error {
+	return fmt.Errorf("expected %s, got %s literal", name, valueAST.GetKind())
+}
+
+// DecimalScalar serializes decimal.Decimal as a string
+var DecimalScalar = NewTextScalar("Decimal", reflect.TypeOf(decimal.Decimal{}))
+
+var (
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// isTextType reports whether t marshals itself to text and back
+func isTextType(t reflect.Type) bool {
+	pt := reflect.PtrTo(t)
+	return pt.Implements(textMarshalerType) && pt.Implements(textUnmarshalerType)
+}
+
+// isJSONType reports whether t marshals itself to JSON and back
+func isJSONType(t reflect.Type) bool {
+	pt := reflect.PtrTo(t)
+	return pt.Implements(jsonMarshalerType) && pt.Implements(jsonUnmarshalerType)
+}
+
+// NewTextScalar builds a string scalar for t, values are serialized with
+// encoding.TextMarshaler and parsed with encoding.TextUnmarshaler
+func NewTextScalar(name string, t reflect.Type) *graphql.Scalar {
+	parse := func(text string) (interface{}, error) {
+		ptr := reflect.New(t)
+		if err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+			return nil, err
 		}
+		return ptr.Elem().Interface(), nil
+	}
 
-		return nil
-	},
-	// parseLiteral: gets invoked to parse client input that was passed inline in the query.
-	// value is ast.Value
-	ParseLiteral: func(valueAST ast.Value) interface{} {
-		switch valueAST := valueAST.(type) {
-		case *ast.IntValue:
-			i, err := strconv.ParseInt(valueAST.Value, 10, 64)
+	return NewScalar(ScalarConfig{
+		Name:        name,
+		Description: fmt.Sprintf("%s serialized as a string", name),
+		Serialize: func(value interface{}) (interface{}, error) {
+			ptr, ok := valuePointer(value, t)
+			if !ok {
+				return nil, unexpectedValueError(name, value)
+			}
+			text, err := ptr.Interface().(encoding.TextMarshaler).MarshalText()
 			if err != nil {
-				log.Error(err)
+				return nil, err
+			}
+			return string(text), nil
+		},
+		ParseValue: func(value interface{}) (interface{}, error) {
+			if v, ok := value.(string); ok {
+				return parse(v)
 			}
+			if ptr, ok := valuePointer(value, t); ok {
+				return ptr.Elem().Interface(), nil
+			}
+			return nil, unexpectedValueError(name, value)
+		},
+		ParseLiteral: func(valueAST ast.Value) (interface{}, error) {
+			switch v := valueAST.(type) {
+			case *ast.StringValue:
+				return parse(v.Value)
+			case *ast.IntValue:
+				return parse(v.Value)
+			case *ast.FloatValue:
+				return parse(v.Value)
+			}
+			return nil, unexpectedLiteralError(name, valueAST)
+		},
+	})
+}
 
-			return i
+// NewJSONMarshalerScalar builds a scalar for t, values are serialized with
+// json.Marshaler and parsed with json.Unmarshaler from any JSON value
+func NewJSONMarshalerScalar(name string, t reflect.Type) *graphql.Scalar {
+	parse := func(value interface{}) (interface{}, error) {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
 		}
-		return nil
-	},
-})
-
-var DecimalScalar = graphql.NewScalar(graphql.ScalarConfig{
-	Name:        "Decimal",
-	Description: ``,
-	Serialize: func(value interface{}) interface{} {
-		switch value := value.(type) {
-		case *decimal.Decimal:
-			return *value
-		case decimal.Decimal:
-			return value
-		default:
-			log.Errorf("Value is not decimal, actial type is %v", value)
+		ptr := reflect.New(t)
+		if err := ptr.Interface().(json.Unmarshaler).UnmarshalJSON(data); err != nil {
+			return nil, err
 		}
+		return ptr.Elem().Interface(), nil
+	}
 
-		return nil
-	},
-	// parseValue: gets invoked to parse client input that was passed through variables.
-	// value is plain type
-	ParseValue: func(value interface{}) interface{} {
-		switch value := value.(type) {
-		case *decimal.Decimal:
-			return *value
-		case decimal.Decimal:
-			return value
-		case string:
-			i, err := decimal.NewFromString(value)
+	return NewScalar(ScalarConfig{
+		Name:        name,
+		Description: fmt.Sprintf("%s serialized as a JSON value", name),
+		Serialize: func(value interface{}) (interface{}, error) {
+			ptr, ok := valuePointer(value, t)
+			if !ok {
+				return nil, unexpectedValueError(name, value)
+			}
+			data, err := ptr.Interface().(json.Marshaler).MarshalJSON()
 			if err != nil {
-				log.Errorf("Cannot convert %v to decimal, %s", value, err)
+				return nil, err
+			}
+			var out interface{}
+			if err := json.Unmarshal(data, &out); err != nil {
+				return nil, err
+			}
+			return out, nil
+		},
+		ParseValue: func(value interface{}) (interface{}, error) {
+			if ptr, ok := valuePointer(value, t); ok {
+				return ptr.Elem().Interface(), nil
 			}
-			return i
-		default:
-			log.Errorf("Value is not decimal, actial type is %v", value)
+			return parse(value)
+		},
+		ParseLiteral: func(valueAST ast.Value) (interface{}, error) {
+			value, err := literalValue(valueAST)
+			if err != nil {
+				return nil, err
+			}
+			return parse(value)
+		},
+	})
+}
+
+// valuePointer returns a pointer to a copy of value, which must be a t or a
+// non-nil *t, so methods with pointer receivers can be called
+func valuePointer(value interface{}, t reflect.Type) (reflect.Value, bool) {
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Ptr && v.Type().Elem() == t {
+		if v.IsNil() {
+			return reflect.Value{}, false
 		}
+		v = v.Elem()
+	}
+	if !v.IsValid() || v.Type() != t {
+		return reflect.Value{}, false
+	}
+	ptr := reflect.New(t)
+	ptr.Elem().Set(v)
+	return ptr, true
+}
 
-		return nil
-	},
-	// parseLiteral: gets invoked to parse client input that was passed inline in the query.
-	// value is ast.Value
-	ParseLiteral: func(valueAST ast.Value) interface{} {
-		switch valueAST := valueAST.(type) {
-		case *ast.StringValue:
-			i, err := decimal.NewFromString(valueAST.Value)
+// literalValue converts a literal to the Go value its JSON form decodes to,
+// objects and lists are converted recursively. Variables are rejected, the
+// value of a variable nested in a literal is not passed to scalars.
+func literalValue(valueAST ast.Value) (interface{}, error) {
+	switch v := valueAST.(type) {
+	case *ast.StringValue:
+		return v.Value, nil
+	case *ast.EnumValue:
+		return v.Value, nil
+	case *ast.BooleanValue:
+		return v.Value, nil
+	case *ast.IntValue:
+		if i, err := strconv.ParseInt(v.Value, 10, 64); err == nil {
+			return i, nil
+		}
+		return strconv.ParseFloat(v.Value, 64)
+	case *ast.FloatValue:
+		return strconv.ParseFloat(v.Value, 64)
+	case *ast.ListValue:
+		items := make([]interface{}, len(v.Values))
+		for i, item := range v.Values {
+			value, err := literalValue(item)
 			if err != nil {
-				log.Error(err)
+				return nil, err
 			}
-
-			return i
+			items[i] = value
 		}
-		return nil
-	},
-})
+		return items, nil
+	case *ast.ObjectValue:
+		fields := make(map[string]interface{}, len(v.Fields))
+		for _, f := range v.Fields {
+			value, err := literalValue(f.Value)
+			if err != nil {
+				return nil, err
+			}
+			fields[f.Name.Value] = value
+		}
+		return fields, nil
+	case *ast.Variable:
+		return nil, fmt.Errorf("variable $%s is not supported inside a literal", v.Name.Value)
+	}
+	return nil, nil
+}
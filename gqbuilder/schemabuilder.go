@@ -2,11 +2,14 @@ package gqbuilder
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/graphql-go/graphql"
 	"github.com/mirogindev/gomer/logger"
+	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
 	"reflect"
+	"time"
 )
 
 const (
@@ -26,16 +29,23 @@ const (
 	OUTPUT_TYPE = "OUTPUT_TYPE"
 )
 
+// defaultScalars maps Go types to the built-in scalars, scalars registered
+// by type or by name take precedence over them
+var defaultScalars = map[reflect.Type]*graphql.Scalar{
+	reflect.TypeOf(""):                graphql.String,
+	reflect.TypeOf(int(0)):            graphql.Int,
+	reflect.TypeOf(int64(0)):          Int64Scalar,
+	reflect.TypeOf(decimal.Decimal{}): DecimalScalar,
+	reflect.TypeOf(float64(0)):        graphql.Float,
+	reflect.TypeOf(float32(0)):        graphql.Float,
+	reflect.TypeOf(time.Time{}):       graphql.DateTime,
+	reflect.TypeOf(false):             graphql.Boolean,
+	reflect.TypeOf(json.RawMessage{}): JSONScalar,
+}
+
+// defaultScalarsMap holds the built-in scalars matched by type name only
 var defaultScalarsMap = map[string]*graphql.Scalar{
-	"string":   graphql.String,
-	"int":      graphql.Int,
-	"int64":    Int64Scalar,
-	"Decimal":  DecimalScalar,
-	"float64":  graphql.Float,
-	"float32":  graphql.Float,
 	"datetime": graphql.DateTime,
-	"Time":     graphql.DateTime,
-	"bool":     graphql.Boolean,
 }
 
 type BuildObject struct {
@@ -44,15 +54,35 @@ type BuildObject struct {
 }
 
 type SchemaBuilder struct {
+	errorRecorder
 	subscriptions  *SubscriptionObject
 	scalars        map[string]*graphql.Scalar
+	scalarTypes    map[reflect.Type]*graphql.Scalar
+	autoScalars    map[reflect.Type]*graphql.Scalar
+	int64AsString  bool
+	enums          map[reflect.Type]*graphql.Enum
 	objects        map[string]GomerObject
 	customObjects  map[string]GomerObject
+	interfaces     map[reflect.Type]*InterfaceObject
+	unions         map[reflect.Type]*UnionObject
 	outputsToBuild map[string]*BuildObject
 	inputsToBuild  map[string]*BuildObject
 	argsMap        map[string]map[string]interface{}
 	builtOutputs   map[string]graphql.Output
 	builtInputs    map[string]graphql.Input
+	builtIfaces    map[string]*graphql.Interface
+	builtUnions    map[string]*graphql.Union
+	objectIfaces   map[string][]*graphql.Interface
+	typeNaming     TypeNamingFn
+	fieldNaming    FieldNamingFn
+	typeNames      map[reflect.Type]string
+	typeNameOwners map[string][]reflect.Type
+	inputPrefix    string
+	inputSuffix    string
+	inputNames     map[string]string
+	strict         bool
+	skippedFields  map[string]SkippedField
+	decoders       *argsDecoders
 }
 
 func GetBuilder() *SchemaBuilder {
@@ -70,19 +100,37 @@ func (s *SchemaBuilder) FindObjectsToBuild() (map[string]*BuildObject, map[strin
 	for _, gm := range s.customObjects {
 		v := gm.(*Object)
 		t := reflect.TypeOf(v.Type)
-		key := getKey(t)
+		key := s.typeName(t)
+		s.registerTypeName(key, t)
 		s.outputsToBuild[key] = &BuildObject{RType: t}
 		s.findMethodObjectsRecursive(v)
 	}
 
 	for _, gm := range s.objects {
 		t := reflect.TypeOf(gm.GetType())
-		key := getKey(t)
+		key := s.typeName(t)
 		s.outputsToBuild[key] = &BuildObject{RType: t}
 		s.findMethodObjectsRecursive(gm)
 
 	}
 
+	for _, io := range s.interfaces {
+		s.registerTypeName(io.Name, io.Interface)
+		for _, impl := range io.Implementations {
+			s.processObject(reflect.TypeOf(impl), OUTPUT_TYPE)
+		}
+		s.findDependentObjects(reflect.TypeOf(io.Type), OUTPUT_TYPE)
+	}
+
+	for _, uo := range s.unions {
+		s.registerTypeName(uo.Name, uo.Interface)
+		for _, m := range uo.Types {
+			s.processObject(reflect.TypeOf(m), OUTPUT_TYPE)
+		}
+	}
+
+	s.resolveInputNames()
+
 	logger.GetLogger().Infof("Build objects tree success, Found %v inputs and %v outputs to build", len(s.inputsToBuild), len(s.outputsToBuild))
 	return s.inputsToBuild, s.outputsToBuild
 }
@@ -95,41 +143,154 @@ func (s *SchemaBuilder) CreateObjects() (map[string]graphql.Input, map[string]gr
 	if s.builtOutputs == nil {
 		s.builtOutputs = make(map[string]graphql.Output)
 	}
+	if s.builtIfaces == nil {
+		s.builtIfaces = make(map[string]*graphql.Interface)
+	}
+	if s.objectIfaces == nil {
+		s.objectIfaces = make(map[string][]*graphql.Interface)
+	}
+	if s.builtUnions == nil {
+		s.builtUnions = make(map[string]*graphql.Union)
+	}
+
+	for _, io := range s.interfaces {
+		iface := graphql.NewInterface(graphql.InterfaceConfig{
+			Name:        io.Name,
+			Description: io.Description,
+			Fields:      graphql.Fields{},
+			ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+				return s.resolveObjectType(p.Value)
+			},
+		})
+		s.builtIfaces[io.Name] = iface
+		for _, impl := range io.Implementations {
+			key := s.typeName(reflect.TypeOf(impl))
+			s.objectIfaces[key] = append(s.objectIfaces[key], iface)
+		}
+	}
 
 	for n, _ := range s.inputsToBuild {
 		//v := o.RType
 		fields := graphql.InputObjectConfigFieldMap{}
 
 		obj := graphql.NewInputObject(graphql.InputObjectConfig{
-			Name:   n,
+			Name:   s.inputNames[n],
 			Fields: fields,
 		})
 		s.builtInputs[n] = obj
 	}
 
-	for n, _ := range s.outputsToBuild {
-		//	v := o.RType
+	for n, o := range s.outputsToBuild {
 		if n == Query || n == Mutation {
 			continue
 		}
 		fields := graphql.Fields{}
 
+		var description string
+		if co, ok := s.customObjects[s.typeName(o.RType)]; ok {
+			description = co.(*Object).Description
+		}
+
 		obj := graphql.NewObject(graphql.ObjectConfig{
-			Name:   n,
-			Fields: fields,
+			Name:        n,
+			Description: description,
+			Fields:      fields,
+			Interfaces:  s.objectIfaces[n],
 		})
 		s.builtOutputs[n] = obj
 	}
+
+	for _, uo := range s.unions {
+		types := make([]*graphql.Object, 0, len(uo.Types))
+		for _, m := range uo.Types {
+			types = append(types, s.builtOutputs[s.typeName(reflect.TypeOf(m))].(*graphql.Object))
+		}
+		s.builtUnions[uo.Name] = graphql.NewUnion(graphql.UnionConfig{
+			Name:        uo.Name,
+			Description: uo.Description,
+			Types:       types,
+			ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+				return s.resolveObjectType(p.Value)
+			},
+		})
+	}
 	return s.builtInputs, s.builtOutputs
 }
 
+// Interface registers a GraphQL interface for a Go interface. The iface
+// argument is a nil pointer to the interface, e.g. (*FeedItem)(nil), and
+// fields is a struct which declares the fields shared by all implementations.
+func (s *SchemaBuilder) Interface(name string, iface interface{}, fields interface{}) *InterfaceObject {
+	obj := &InterfaceObject{
+		Name: name,
+		Type: fields,
+	}
+
+	it := reflect.TypeOf(iface)
+	if it == nil || it.Kind() != reflect.Ptr || it.Elem().Kind() != reflect.Interface {
+		s.addError(&BuildError{
+			Object:    name,
+			Signature: fmt.Sprint(it),
+			Expected:  "nil pointer to a Go interface",
+			Message:   fmt.Sprintf("Interface %s must be passed as a nil pointer to a Go interface, got %v", name, it),
+		})
+		return obj
+	}
+	if ft := reflect.TypeOf(fields); ft == nil || ft.Kind() != reflect.Struct {
+		s.addError(&BuildError{
+			Object:    name,
+			Signature: fmt.Sprint(ft),
+			Expected:  "struct",
+			Message:   fmt.Sprintf("Fields of interface %s must be a struct, got %v", name, ft),
+		})
+		return obj
+	}
+	obj.Interface = it.Elem()
+	if !s.checkInterfaces(name, it.Elem()) {
+		return obj
+	}
+
+	s.interfaces[it.Elem()] = obj
+	return obj
+}
+
+// Union registers a GraphQL union for a marker Go interface. The iface
+// argument is a nil pointer to the interface, e.g. (*SearchResult)(nil), and
+// types are the member structs.
+func (s *SchemaBuilder) Union(name string, iface interface{}, types ...interface{}) *UnionObject {
+	obj := &UnionObject{
+		Name:  name,
+		Types: types,
+	}
+
+	it := reflect.TypeOf(iface)
+	if it == nil || it.Kind() != reflect.Ptr || it.Elem().Kind() != reflect.Interface {
+		s.addError(&BuildError{
+			Object:    name,
+			Signature: fmt.Sprint(it),
+			Expected:  "nil pointer to a Go interface",
+			Message:   fmt.Sprintf("Union %s must be passed as a nil pointer to a Go interface, got %v", name, it),
+		})
+		return obj
+	}
+	obj.Interface = it.Elem()
+	if !s.checkUnions(name, it.Elem()) {
+		return obj
+	}
+
+	s.unions[it.Elem()] = obj
+	return obj
+}
+
 func (s *SchemaBuilder) Query() *Object {
 	name := Query
-	s.checkObjects(name)
 	obj := &Object{
 		Name: name,
 		Type: query{},
 	}
+	if !s.checkObjects(name) {
+		return obj
+	}
 
 	s.objects[name] = obj
 	return obj
@@ -137,12 +298,13 @@ func (s *SchemaBuilder) Query() *Object {
 
 func (s *SchemaBuilder) Subscription() *SubscriptionObject {
 	name := Subscription
-	s.checkObjects(name)
-
 	obj := &SubscriptionObject{
 		Name: name,
 		Type: subscription{},
 	}
+	if !s.checkObjects(name) {
+		return obj
+	}
 
 	s.objects[name] = obj
 	return obj
@@ -150,85 +312,223 @@ func (s *SchemaBuilder) Subscription() *SubscriptionObject {
 
 func (s *SchemaBuilder) Mutation() *Object {
 	name := Mutation
-	s.checkObjects(name)
-
 	obj := &Object{
 		Name: name,
 		Type: mutation{},
 	}
+	if !s.checkObjects(name) {
+		return obj
+	}
 
 	s.objects[name] = obj
 	return obj
 }
 
 func (s *SchemaBuilder) Object(name string, objType interface{}) *Object {
-	s.checkCustomObjects(name)
-
 	obj := &Object{
 		Name: name,
 		Type: objType,
 	}
+	if !s.checkCustomObjects(name) {
+		return obj
+	}
+	s.SetTypeName(objType, name)
 
 	s.customObjects[name] = obj
 	return obj
 }
 
-func (s *SchemaBuilder) checkCustomObjects(name string) {
+func (s *SchemaBuilder) checkCustomObjects(name string) bool {
 	if s.customObjects == nil {
 		s.customObjects = make(map[string]GomerObject)
 	}
 	if _, ok := s.customObjects[name]; ok {
-		log.Panicf("Cutsom object with name %s aready exists", name)
+		s.addErrorf(name, "", "Cutsom object with name %s aready exists", name)
+		return false
 	}
+	return true
 }
 
-func (s *SchemaBuilder) checkObjects(name string) {
+func (s *SchemaBuilder) checkInterfaces(name string, t reflect.Type) bool {
+	if s.interfaces == nil {
+		s.interfaces = make(map[reflect.Type]*InterfaceObject)
+	}
+	if _, ok := s.interfaces[t]; ok {
+		s.addErrorf(name, "", "Interface for type %s aready exists", t)
+		return false
+	}
+	for _, io := range s.interfaces {
+		if io.Name == name {
+			s.addErrorf(name, "", "Interface with name %s aready exists", name)
+			return false
+		}
+	}
+	return true
+}
+
+func (s *SchemaBuilder) checkUnions(name string, t reflect.Type) bool {
+	if s.unions == nil {
+		s.unions = make(map[reflect.Type]*UnionObject)
+	}
+	if _, ok := s.unions[t]; ok {
+		s.addErrorf(name, "", "Union for type %s aready exists", t)
+		return false
+	}
+	for _, uo := range s.unions {
+		if uo.Name == name {
+			s.addErrorf(name, "", "Union with name %s aready exists", name)
+			return false
+		}
+	}
+	return true
+}
+
+func (s *SchemaBuilder) checkObjects(name string) bool {
 	if s.objects == nil {
 		s.objects = make(map[string]GomerObject)
 	}
 	if _, ok := s.objects[name]; ok {
-		log.Panicf("Resolver with name %s aready exists", name)
+		s.addErrorf(name, "", "Resolver with name %s aready exists", name)
+		return false
 	}
+	return true
 }
 
-func (s *SchemaBuilder) checkScalars(name string) {
+func (s *SchemaBuilder) checkScalars(name string) bool {
 	if s.scalars == nil {
 		s.scalars = make(map[string]*graphql.Scalar)
 	}
 	if _, ok := s.scalars[name]; ok {
-		log.Panicf("Scalar with name %s aready exists", name)
+		s.addErrorf(name, "", "Scalar with name %s aready exists", name)
+		return false
 	}
+	return true
 }
 
-func (s *SchemaBuilder) checkSubscriptions(name string) {
+func (s *SchemaBuilder) checkSubscriptions(name string) bool {
 	if s.subscriptions == nil {
 		s.subscriptions = &SubscriptionObject{}
 	}
 	if _, ok := s.subscriptions.Methods[name]; ok {
-		log.Panicf("Subscription with name %s aready exists", name)
+		s.addErrorf(Subscription, name, "Subscription with name %s aready exists", name)
+		return false
 	}
+	return true
 }
 
-func (s *SchemaBuilder) buildFieldConfigArgument(t reflect.Type) graphql.FieldConfigArgument {
+func (s *SchemaBuilder) buildFieldConfigArgument(path string, t reflect.Type, fc *FieldConfig) graphql.FieldConfigArgument {
 	fields := graphql.FieldConfigArgument{}
 
-	for i := 0; i < t.NumField(); i++ {
-		f := t.Field(i)
+	for _, f := range s.schemaFields(t, path, true) {
+		fName := s.fieldName(f)
+		tags := findGomerTags(f)
+		it := s.getResolverInputObjectRecursive(f.Type)
+		if it == nil {
+			s.addError(&BuildError{
+				Object:    path,
+				Field:     fName,
+				Signature: f.Type.String(),
+				Expected:  "argument of a struct, scalar or enum type, or a pointer or slice of them",
+				Message:   fmt.Sprintf("Argument %s of %s has type %s which has no GraphQL mapping", fName, path, f.Type),
+			})
+			continue
+		}
+		io := applyNullabilityTags(it, tags)
+		ac := &graphql.ArgumentConfig{
+			Type:        io,
+			Description: tags.GetOptionalParam("description"),
+		}
+		if c, ok := fc.args[fName]; ok {
+			if c.description != "" {
+				ac.Description = c.description
+			}
+			if c.defaultValue != nil {
+				// an arg with a default value can be omitted
+				ac.Type = MakeObjectNullable(io)
+				ac.DefaultValue = c.defaultValue
+			}
+		}
 
-		io := s.getResolverInputObjectRecursive(f.Type)
+		fields[fName] = ac
+	}
 
-		fields[getFieldName(f.Name)] = &graphql.ArgumentConfig{
-			Type: io,
+	return fields
+}
+
+// validateFieldConfigs checks that the configured args of every resolver
+// exist in its args struct
+func (s *SchemaBuilder) validateFieldConfigs() []error {
+	errs := make([]error, 0)
+	check := func(o string, n string, fn interface{}, hasParent bool, fc *FieldConfig) {
+		args := s.getResolverArgs(fn, hasParent)
+		for a := range fc.args {
+			found := false
+			if args != nil && args.Kind() == reflect.Struct {
+				for _, f := range structFields(args) {
+					if s.fieldName(f) == a {
+						found = true
+					}
+				}
+			}
+			if !found {
+				errs = append(errs, &BuildError{
+					Object:  o,
+					Field:   n,
+					Message: fmt.Sprintf("Field %s of object %s has no arg %s", n, o, a),
+				})
+			}
 		}
 	}
 
-	return fields
+	for _, gm := range s.allObjects() {
+		switch o := gm.(type) {
+		case *Object:
+			for n, m := range o.Methods {
+				check(o.Name, n, m.Fn, !o.isRoot(), &m.FieldConfig)
+			}
+		case *SubscriptionObject:
+			for n, m := range o.Methods {
+				check(o.Name, n, m.Fn, true, &m.FieldConfig)
+			}
+		}
+	}
+	return errs
+}
+
+// allObjects returns the root and the custom objects
+func (s *SchemaBuilder) allObjects() []GomerObject {
+	objects := make([]GomerObject, 0, len(s.objects)+len(s.customObjects))
+	for _, gm := range s.objects {
+		objects = append(objects, gm)
+	}
+	for _, gm := range s.customObjects {
+		objects = append(objects, gm)
+	}
+	return objects
+}
+
+// registrationErrors returns the mistakes recorded while registering types,
+// objects and resolvers
+func (s *SchemaBuilder) registrationErrors() []error {
+	errs := append([]error{}, s.errors...)
+	for _, gm := range s.allObjects() {
+		switch o := gm.(type) {
+		case *Object:
+			errs = append(errs, o.errors...)
+		case *SubscriptionObject:
+			errs = append(errs, o.errors...)
+		}
+	}
+	for _, io := range s.interfaces {
+		errs = append(errs, io.errors...)
+	}
+	return errs
 }
 
 func (s *SchemaBuilder) buildQuery() *graphql.Object {
 	if qf, ok := s.objects[Query]; ok {
 		fields := s.buildMethods(qf.(*Object))
-		rootQuery := graphql.NewObject(graphql.ObjectConfig{Name: Query, Fields: fields})
+		rootQuery := graphql.NewObject(graphql.ObjectConfig{Name: Query, Description: qf.(*Object).Description, Fields: fields})
 
 		return rootQuery
 	}
@@ -239,7 +539,7 @@ func (s *SchemaBuilder) buildQuery() *graphql.Object {
 func (s *SchemaBuilder) buildMutation() *graphql.Object {
 	if qf, ok := s.objects[Mutation]; ok {
 		fields := s.buildMethods(qf.(*Object))
-		rootMutation := graphql.NewObject(graphql.ObjectConfig{Name: Mutation, Fields: fields})
+		rootMutation := graphql.NewObject(graphql.ObjectConfig{Name: Mutation, Description: qf.(*Object).Description, Fields: fields})
 
 		return rootMutation
 	}
@@ -250,7 +550,7 @@ func (s *SchemaBuilder) buildMutation() *graphql.Object {
 func (s *SchemaBuilder) buildSubscription() *graphql.Object {
 	if sf, ok := s.objects[Subscription]; ok {
 		fields := s.buildSubscriptionMethods(sf.(*SubscriptionObject))
-		rootSubscription := graphql.NewObject(graphql.ObjectConfig{Name: Subscription, Fields: fields})
+		rootSubscription := graphql.NewObject(graphql.ObjectConfig{Name: Subscription, Description: sf.(*SubscriptionObject).Description, Fields: fields})
 
 		return rootSubscription
 	}
@@ -265,7 +565,7 @@ func (s *SchemaBuilder) findMethodObjectsRecursive(gm GomerObject) {
 			ro := s.findResolverOutputObject(v.Fn)
 			s.processObject(ro, OUTPUT_TYPE)
 
-			ao := s.findResolverArgsObject(v.Fn)
+			ao := s.findResolverArgsObject(v.Fn, !o.isRoot())
 			if ao == nil {
 				continue
 			}
@@ -280,7 +580,7 @@ func (s *SchemaBuilder) findMethodObjectsRecursive(gm GomerObject) {
 			ro := s.findSubscriptionOutputObject(v.Output)
 			s.processObject(ro, OUTPUT_TYPE)
 
-			ao := s.findResolverArgsObject(v.Fn)
+			ao := s.findResolverArgsObject(v.Fn, true)
 
 			if ao == nil {
 				continue
@@ -291,10 +591,18 @@ func (s *SchemaBuilder) findMethodObjectsRecursive(gm GomerObject) {
 }
 
 func (s *SchemaBuilder) processObject(t reflect.Type, objType string) {
-	if _, ok := s.isScalar(t); ok {
+	if _, ok := s.isLeaf(t); ok {
+		return
+	}
+	if s.isAbstract(t) {
 		return
 	}
-	key := getKey(t)
+	if t.Kind() != reflect.Struct {
+		// types without a GraphQL mapping are reported where they are used
+		return
+	}
+	key := s.typeName(t)
+	s.registerTypeName(key, t)
 	if objType == INPUT_TYPE {
 		s.inputsToBuild[key] = &BuildObject{RType: t}
 	} else if objType == OUTPUT_TYPE {
@@ -310,67 +618,155 @@ func (s *SchemaBuilder) CreateObjectsFields() (map[string]graphql.Input, map[str
 		t := o.RType
 		ignoredFields := o.IgnoredFields
 		bo := s.builtInputs[n].(*graphql.InputObject)
-		for i := 0; i < t.NumField(); i++ {
-			f := t.Field(i)
+		fields := s.supportedFields(t, true)
+		if t.Name() != "" {
+			// anonymous args structs are reported with their resolvers
+			fields = s.schemaFields(t, t.String(), true)
+		}
+		count := 0
+		for _, f := range fields {
 			if ignoredFields != nil && ignoredFields[f.Name] != "" {
 				continue
 			}
-			fName := getFieldName(f.Name)
-			of := s.createInputField(f.Name, f, true)
+			fName := s.fieldName(f)
+			of := s.createInputField(f.Name, f, !isPromotedThroughPointer(t, f.Index))
+			if of == nil {
+				s.skipField(fmt.Sprintf("%s.%s", t, f.Name), f.Type, "type has no GraphQL input mapping")
+				continue
+			}
 			bo.AddFieldConfig(fName, of)
+			count++
+		}
+		if count == 0 {
+			s.addError(noFieldsError("Input", n, t))
 		}
 	}
 	for n, o := range s.outputsToBuild {
 		t := o.RType
+		ignoredFields := o.IgnoredFields
 		bo := s.builtOutputs[n].(*graphql.Object)
-		co := s.customObjects[n]
-		for i := 0; i < t.NumField(); i++ {
+		co := s.customObjects[s.typeName(t)]
+		attached := make(map[string]bool)
+		count := 0
+		for _, f := range s.schemaFields(t, t.String(), false) {
 			var of *graphql.Field
-			f := t.Field(i)
-			fName := getFieldName(f.Name)
+			if ignoredFields != nil && ignoredFields[f.Name] != "" {
+				continue
+			}
+			fName := s.fieldName(f)
 			if co != nil {
 				_co := co.(*Object)
 				if v, ok := _co.Methods[fName]; ok {
 					of = s.buildMethod(fName, v, _co)
+					attached[fName] = true
+					if of == nil {
+						continue
+					}
 				} else {
-					of = s.createOutputField(f.Name, f.Type, true)
+					of = s.createOutputField(f.Name, t, f, !isPromotedThroughPointer(t, f.Index))
 				}
 			} else {
-				of = s.createOutputField(f.Name, f.Type, true)
+				of = s.createOutputField(f.Name, t, f, !isPromotedThroughPointer(t, f.Index))
+			}
+			if of == nil {
+				s.skipField(fmt.Sprintf("%s.%s", t, f.Name), f.Type, "type has no GraphQL output mapping")
+				continue
 			}
 			bo.AddFieldConfig(fName, of)
+			count++
+		}
+		if co != nil {
+			// resolvers which do not match a struct field become computed fields
+			_co := co.(*Object)
+			for mn, m := range _co.Methods {
+				if attached[mn] {
+					continue
+				}
+				if of := s.buildMethod(mn, m, _co); of != nil {
+					bo.AddFieldConfig(mn, of)
+					count++
+				}
+			}
+		}
+		if count == 0 && !s.isRootType(t) {
+			s.addError(noFieldsError("Object", n, t))
+		}
+	}
+	for _, io := range s.interfaces {
+		t := reflect.TypeOf(io.Type)
+		bi := s.builtIfaces[io.Name]
+		count := 0
+		for _, f := range s.schemaFields(t, t.String(), false) {
+			of := s.createOutputField(f.Name, t, f, !isPromotedThroughPointer(t, f.Index))
+			if of == nil {
+				s.skipField(fmt.Sprintf("%s.%s", t, f.Name), f.Type, "type has no GraphQL output mapping")
+				continue
+			}
+			bi.AddFieldConfig(s.fieldName(f), of)
+			count++
+		}
+		if count == 0 {
+			s.addError(noFieldsError("Interface", io.Name, t))
 		}
 	}
 	return s.builtInputs, s.builtOutputs
 }
 
+// isRootType reports whether t backs the query, mutation or subscription,
+// those get their fields from resolvers
+func (s *SchemaBuilder) isRootType(t reflect.Type) bool {
+	for _, o := range s.objects {
+		if reflect.TypeOf(o.GetType()) == t {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *SchemaBuilder) createInputField(fieldName string, sf reflect.StructField, required bool) *graphql.InputObjectFieldConfig {
-	fType := s.getInputFieldTypeRecursive(sf, sf.Type, true)
+	fType := s.getInputFieldTypeRecursive(sf, sf.Type, required)
 	if fType == nil {
-		log.Errorf("Cannot create input field %s", fieldName)
 		return nil
 	}
+	tags := findGomerTags(sf)
 	field := &graphql.InputObjectFieldConfig{
-		Type: fType,
+		Type:        applyNullabilityTags(fType, tags),
+		Description: tags.GetOptionalParam("description"),
 	}
 	return field
 }
 
-func (s *SchemaBuilder) createOutputField(fieldName string, t reflect.Type, required bool) *graphql.Field {
-	fType := s.getOutputFieldTypeRecursive(t, true)
+func (s *SchemaBuilder) createOutputField(fieldName string, owner reflect.Type, sf reflect.StructField, required bool) *graphql.Field {
+	fType := s.getOutputFieldTypeRecursive(sf, sf.Type, required)
 	if fType == nil {
-		log.Errorf("Cannot create output field %s", fieldName)
 		return nil
 	}
+	tags := findGomerTags(sf)
 	field := &graphql.Field{
-		Name: fieldName,
-		Type: fType,
+		Name:              fieldName,
+		Type:              applyNullabilityTags(fType, tags),
+		Description:       tags.GetOptionalParam("description"),
+		DeprecationReason: tags.GetOptionalParam("deprecated"),
+		Resolve:           structFieldResolver(owner, sf, s.kindConverter(sf.Type)),
 	}
 	return field
 }
 
+// applyNullabilityTags applies the required and nullable tag flags, which
+// override the nullability derived from the Go type
+func applyNullabilityTags(t graphql.Output, tags GomerTags) graphql.Output {
+	if _, ok := tags.ParamExist("required"); ok {
+		return graphql.NewNonNull(MakeObjectNullable(t))
+	}
+	if _, ok := tags.ParamExist("nullable"); ok {
+		return MakeObjectNullable(t)
+	}
+	return t
+}
+
 func (s *SchemaBuilder) getOutputFieldType(v graphql.Output, required bool) graphql.Output {
-	if required {
+	// JSON values such as maps may be nil, the required tag makes them non-null
+	if required && v != graphql.Output(JSONScalar) {
 		return graphql.NewNonNull(v)
 	}
 
@@ -379,7 +775,8 @@ func (s *SchemaBuilder) getOutputFieldType(v graphql.Output, required bool) grap
 }
 
 func (s *SchemaBuilder) getInputFieldType(v graphql.Input, required bool) graphql.Input {
-	if required {
+	// JSON values such as maps may be nil, the required tag makes them non-null
+	if required && v != graphql.Input(JSONScalar) {
 		return graphql.NewNonNull(v)
 	}
 
@@ -392,7 +789,7 @@ func (s *SchemaBuilder) getInputFieldTypeRecursive(sf reflect.StructField, t ref
 
 	case reflect.Ptr:
 		return s.getInputFieldTypeRecursive(sf, t.Elem(), false)
-	case reflect.Slice:
+	case reflect.Slice, reflect.Array:
 		if v, ok := s.isScalar(t); ok {
 			return s.getInputFieldType(v, required)
 		} else {
@@ -402,49 +799,46 @@ func (s *SchemaBuilder) getInputFieldTypeRecursive(sf reflect.StructField, t ref
 		if v, ok := s.isScalar(t); ok {
 			return s.getInputFieldType(v, required)
 		} else {
-			var key string
-			tags := findGomerTags(sf)
-			if fv, ok := tags.ParamExist("ignoreFields"); ok {
-				ignoredFields := stringToMap(fv)
-				key = getKeyWithHash(t, ignoredFields)
-			} else {
-				key = getKey(t)
-			}
+			key, _ := s.getFieldTypeKey(sf, t)
 			v := s.builtInputs[key]
 			return s.getInputFieldType(v, required)
 		}
 	}
 
-	if v, ok := s.isScalar(t); ok {
+	if v, ok := s.isLeaf(t); ok {
 		return s.getInputFieldType(v, required)
 	}
 
 	return nil
 }
 
-func (s *SchemaBuilder) getOutputFieldTypeRecursive(t reflect.Type, required bool) graphql.Output {
+func (s *SchemaBuilder) getOutputFieldTypeRecursive(sf reflect.StructField, t reflect.Type, required bool) graphql.Output {
 	//var field *graphql.Field
 	switch t.Kind() {
 
 	case reflect.Ptr:
-		return s.getOutputFieldTypeRecursive(t.Elem(), false)
-	case reflect.Slice:
+		return s.getOutputFieldTypeRecursive(sf, t.Elem(), false)
+	case reflect.Slice, reflect.Array:
 		if v, ok := s.isScalar(t); ok {
 			return s.getOutputFieldType(v, required)
 		} else {
-			return graphql.NewList(s.getOutputFieldTypeRecursive(t.Elem(), true))
+			return graphql.NewList(s.getOutputFieldTypeRecursive(sf, t.Elem(), true))
 		}
 	case reflect.Struct:
 		if v, ok := s.isScalar(t); ok {
 			return s.getOutputFieldType(v, required)
 		} else {
-			key := getKey(t)
+			key, _ := s.getFieldTypeKey(sf, t)
 			v := s.builtOutputs[key]
 			return s.getOutputFieldType(v, required)
 		}
+	case reflect.Interface:
+		if v, ok := s.getAbstractType(t); ok {
+			return s.getOutputFieldType(v, required)
+		}
 	}
 
-	if v, ok := s.isScalar(t); ok {
+	if v, ok := s.isLeaf(t); ok {
 		return s.getOutputFieldType(v, required)
 	}
 
@@ -453,7 +847,17 @@ func (s *SchemaBuilder) getOutputFieldTypeRecursive(t reflect.Type, required boo
 
 func (s *SchemaBuilder) buildMethod(n string, v *Method, o *Object) *graphql.Field {
 	out := s.getResolverOutputObject(v.Fn)
-	args := s.getResolverArgs(v.Fn)
+	if out == nil {
+		s.addError(&BuildError{
+			Object:    o.Name,
+			Field:     n,
+			Signature: reflect.TypeOf(v.Fn).Out(0).String(),
+			Expected:  "result of a struct, scalar, enum, interface or union type, or a pointer or slice of them",
+			Message:   fmt.Sprintf("Resolver %s of object %s returns %s which has no GraphQL mapping", n, o.Name, reflect.TypeOf(v.Fn).Out(0)),
+		})
+		return nil
+	}
+	args := s.getResolverArgs(v.Fn, !o.isRoot())
 	var fieldConfigArgument graphql.FieldConfigArgument
 
 	if args != nil {
@@ -465,55 +869,27 @@ func (s *SchemaBuilder) buildMethod(n string, v *Method, o *Object) *graphql.Fie
 			s.argsMap[o.Name] = make(map[string]interface{})
 		}
 		s.argsMap[o.Name][n] = reflect.New(args).Elem().Interface()
-		fieldConfigArgument = s.buildFieldConfigArgument(args)
+		fieldConfigArgument = s.buildFieldConfigArgument(fmt.Sprintf("%s.%s", o.Name, n), args, &v.FieldConfig)
 	}
 
-	fun := s.getFunc(v.Fn)
+	iv := s.compileResolver(v.Fn, !o.isRoot())
+	decoders := s.getDecoders()
+	convert := s.kindConverter(reflect.TypeOf(v.Fn).Out(0))
 	return &graphql.Field{
-		Args: fieldConfigArgument,
-		Type: out,
+		Args:              fieldConfigArgument,
+		Type:              out,
+		Description:       v.description,
+		DeprecationReason: v.deprecationReason,
 		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 			if p.Context == nil {
 				p.Context = context.Background()
 			}
-			if _, ok := p.Source.(map[string]interface{}); ok {
-				selection := ParseSelections(p, s.argsMap)
-				p.Context = context.WithValue(p.Context, "selection", selection)
-			}
-			in := make([]reflect.Value, fun.Type().NumIn())
-
-			in[0] = reflect.ValueOf(p.Context)
-
-			argType, pos, _ := getArgs(fun.Type())
-
-			if _, ok := p.Source.(map[string]interface{}); !ok {
-				in[pos-1] = reflect.ValueOf(p.Source)
-			}
-
-			if p.Args != nil && len(p.Args) > 0 {
-				args := ReflectStructRecursive(argType, p.Args)
-				in[pos] = args
-			} else {
-				if argType != nil {
-					in[pos] = reflect.New(argType).Elem()
-				}
-			}
-
-			result := fun.Call(in)
-
-			var respData interface{}
-			var err error
-			if result[0].Interface() != nil {
-				respData = result[0].Interface()
-			} else {
-				respData = reflect.New(fun.Type().Out(0)).Elem()
-			}
-
-			if result[1].Interface() != nil {
-				err = result[1].Interface().(error)
+			ctx := withSelection(p.Context, p, s.argsMap, decoders)
+			res, err := iv.call(ctx, p.Source, p.Args)
+			if convert != nil && res != nil {
+				res = convert(reflect.ValueOf(res)).Interface()
 			}
-
-			return respData, err
+			return res, err
 		},
 	}
 }
@@ -524,7 +900,9 @@ func (s *SchemaBuilder) buildMethods(o *Object) graphql.Fields {
 	}
 	fields := graphql.Fields{}
 	for n, v := range o.Methods {
-		fields[n] = s.buildMethod(n, v, o)
+		if f := s.buildMethod(n, v, o); f != nil {
+			fields[n] = f
+		}
 	}
 	return fields
 }
@@ -533,7 +911,17 @@ func (s *SchemaBuilder) buildSubscriptionMethods(so *SubscriptionObject) graphql
 	fields := graphql.Fields{}
 	for n, v := range so.Methods {
 		out, _ := s.getResolverOutputObjectFromType(reflect.TypeOf(v.Output))
-		args := s.getResolverArgs(v.Fn)
+		if out == nil {
+			s.addError(&BuildError{
+				Object:    Subscription,
+				Field:     n,
+				Signature: reflect.TypeOf(v.Fn).String(),
+				Expected:  "output of a struct type",
+				Message:   fmt.Sprintf("Subscription %s returns %s which has no GraphQL mapping", n, reflect.TypeOf(v.Output)),
+			})
+			continue
+		}
+		args := s.getResolverArgs(v.Fn, true)
 
 		var fieldConfigArgument graphql.FieldConfigArgument
 
@@ -542,38 +930,29 @@ func (s *SchemaBuilder) buildSubscriptionMethods(so *SubscriptionObject) graphql
 				s.argsMap = make(map[string]map[string]interface{})
 			}
 
-			if s.argsMap[v.Name] == nil {
-				s.argsMap[v.Name] = make(map[string]interface{})
+			if s.argsMap[so.Name] == nil {
+				s.argsMap[so.Name] = make(map[string]interface{})
 			}
-			s.argsMap[v.Name][n] = reflect.New(args).Elem().Interface()
-			fieldConfigArgument = s.buildFieldConfigArgument(args)
+			s.argsMap[so.Name][n] = reflect.New(args).Elem().Interface()
+			fieldConfigArgument = s.buildFieldConfigArgument(fmt.Sprintf("%s.%s", Subscription, n), args, &v.FieldConfig)
 		}
 
-		fun := s.getFunc(v.Fn)
+		iv := s.compileSubscription(v.Fn)
 		fields[n] = &graphql.Field{
-			Args: fieldConfigArgument,
-			Type: out,
+			Args:              fieldConfigArgument,
+			Type:              out,
+			Description:       v.description,
+			DeprecationReason: v.deprecationReason,
 			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 				return p.Source, nil
 			},
 			Subscribe: func(p graphql.ResolveParams) (interface{}, error) {
 				c := make(chan interface{})
-				in := make([]reflect.Value, fun.Type().NumIn())
-				if p.Context == nil {
-					in[0] = reflect.New(fun.Type().In(0)).Elem()
-				} else {
-					in[0] = reflect.ValueOf(p.Context)
-				}
-				in[1] = reflect.ValueOf(c)
-				if p.Args != nil && len(p.Args) > 0 {
-					argType, _, _ := getArgs(fun.Type())
-					args := ReflectStructRecursive(argType, p.Args)
-					in[2] = args
+				run, err := iv.subscribe(p.Context, c, p.Args)
+				if err != nil {
+					return nil, err
 				}
-
-				go func() {
-					fun.Call(in)
-				}()
+				go run()
 				return c, nil
 			},
 		}
@@ -592,22 +971,14 @@ func (s *SchemaBuilder) findSubscriptionOutputObject(out interface{}) reflect.Ty
 }
 
 func (s *SchemaBuilder) findDependentObjects(t reflect.Type, objType string) {
-	for i := 0; i < t.NumField(); i++ {
-		f := t.Field(i)
-		tags := findGomerTags(f)
-
+	// unsupported fields are reported when the fields of t are built
+	for _, f := range s.supportedFields(t, objType == INPUT_TYPE) {
 		ao := s.getActualTypeRecursive(f.Type)
 
-		_, scalar := s.isScalar(ao)
-		if !scalar {
-			var key = ""
-			var ignoredFields map[string]string
-			if fv, ok := tags.ParamExist("ignoreFields"); ok {
-				ignoredFields = stringToMap(fv)
-				key = getKeyWithHash(ao, ignoredFields)
-			} else {
-				key = getKey(ao)
-			}
+		_, leaf := s.isLeaf(ao)
+		if !leaf && !s.isAbstract(ao) {
+			key, ignoredFields := s.getFieldTypeKey(f, ao)
+			s.registerTypeName(key, ao)
 
 			if objType == INPUT_TYPE {
 				if _, ok := s.inputsToBuild[key]; ok {
@@ -628,11 +999,55 @@ func (s *SchemaBuilder) findDependentObjects(t reflect.Type, objType string) {
 	}
 }
 
+// getFieldTypeKey returns the key of the object built for a struct field type.
+// The ignoreFields tag param builds a separate object without these fields,
+// named with the prefix tag param or with a hash of the ignored fields.
+func (s *SchemaBuilder) getFieldTypeKey(sf reflect.StructField, t reflect.Type) (string, map[string]string) {
+	tags := findGomerTags(sf)
+	var ignoredFields map[string]string
+	if fv, ok := tags.ParamExist("ignoreFields"); ok {
+		ignoredFields = stringToMap(fv)
+	}
+	if prefix, ok := tags.ParamExist("prefix"); ok {
+		return prefix + s.typeName(t), ignoredFields
+	}
+	if ignoredFields != nil {
+		return s.typeNameWithHash(t, ignoredFields), ignoredFields
+	}
+	return s.typeName(t), nil
+}
+
+// SetInputNaming sets the prefix and the suffix added to the name of an input
+// object whose Go type is also built as an output, "Input" suffix by default
+func (s *SchemaBuilder) SetInputNaming(prefix, suffix string) {
+	s.inputPrefix = prefix
+	s.inputSuffix = suffix
+}
+
+// resolveInputNames derives distinct names for inputs which share their name
+// with an output object, an interface or a union
+func (s *SchemaBuilder) resolveInputNames() {
+	prefix, suffix := s.inputPrefix, s.inputSuffix
+	if prefix == "" && suffix == "" {
+		suffix = "Input"
+	}
+
+	s.inputNames = make(map[string]string)
+	for key, o := range s.inputsToBuild {
+		name := key
+		if _, ok := s.outputsToBuild[key]; ok || s.isAbstractName(key) {
+			name = prefix + key + suffix
+			s.registerTypeName(name, o.RType)
+		}
+		s.inputNames[key] = name
+	}
+}
+
 func (s *SchemaBuilder) getActualTypeRecursive(t reflect.Type) reflect.Type {
 	switch t.Kind() {
 	case reflect.Ptr:
 		return s.getActualTypeRecursive(t.Elem())
-	case reflect.Slice:
+	case reflect.Slice, reflect.Array:
 		_, scalar := s.isScalar(t)
 		if scalar {
 			return t
@@ -646,8 +1061,8 @@ func (s *SchemaBuilder) getActualTypeRecursive(t reflect.Type) reflect.Type {
 
 }
 
-func (s *SchemaBuilder) findResolverArgsObject(fn interface{}) reflect.Type {
-	args, _, exist := getArgs(reflect.TypeOf(fn))
+func (s *SchemaBuilder) findResolverArgsObject(fn interface{}, hasParent bool) reflect.Type {
+	args, _, exist := getArgs(reflect.TypeOf(fn), hasParent)
 	if !exist {
 		return nil
 	}
@@ -655,8 +1070,8 @@ func (s *SchemaBuilder) findResolverArgsObject(fn interface{}) reflect.Type {
 	return s.getActualTypeRecursive(args)
 }
 
-func (s *SchemaBuilder) getResolverArgs(fn interface{}) reflect.Type {
-	a := s.findResolverArgsObject(fn)
+func (s *SchemaBuilder) getResolverArgs(fn interface{}, hasParent bool) reflect.Type {
+	a := s.findResolverArgsObject(fn, hasParent)
 	return a
 }
 
@@ -666,44 +1081,141 @@ func (s *SchemaBuilder) getResolverOutputObject(fn interface{}) graphql.Output {
 }
 
 func (s *SchemaBuilder) getResolverOutputObjectRecursive(t reflect.Type) graphql.Output {
+	// struct, slice and array types may map to scalars
+	if l, ok := s.isLeaf(t); ok {
+		if l == graphql.Leaf(JSONScalar) {
+			return l
+		}
+		return graphql.NewNonNull(l)
+	}
 	switch t.Kind() {
 	case reflect.Ptr:
 		return MakeObjectNullable(s.getResolverOutputObjectRecursive(t.Elem()))
-	case reflect.Slice:
-		return graphql.NewNonNull(graphql.NewList(s.getResolverOutputObjectRecursive(t.Elem())))
+	case reflect.Slice, reflect.Array:
+		if v := s.getResolverOutputObjectRecursive(t.Elem()); v != nil {
+			return graphql.NewNonNull(graphql.NewList(v))
+		}
 	case reflect.Struct:
-		return graphql.NewNonNull(s.builtOutputs[getKey(t)])
+		if v, ok := s.builtOutputs[s.typeName(t)]; ok {
+			return graphql.NewNonNull(v)
+		}
+	case reflect.Interface:
+		if v, ok := s.getAbstractType(t); ok {
+			return graphql.NewNonNull(v)
+		}
 	}
 
-	panic("Invalid output type")
+	return nil
 }
 
 func (s *SchemaBuilder) getResolverInputObjectRecursive(t reflect.Type) graphql.Input {
+	// struct, slice and array types may map to scalars
+	if l, ok := s.isLeaf(t); ok {
+		if l == graphql.Leaf(JSONScalar) {
+			return l
+		}
+		return graphql.NewNonNull(l)
+	}
 	switch t.Kind() {
 	case reflect.Ptr:
 		return MakeObjectNullable(s.getResolverInputObjectRecursive(t.Elem()))
-	case reflect.Slice:
-		return graphql.NewNonNull(graphql.NewList(s.getResolverInputObjectRecursive(t.Elem())))
+	case reflect.Slice, reflect.Array:
+		if v := s.getResolverInputObjectRecursive(t.Elem()); v != nil {
+			return graphql.NewNonNull(graphql.NewList(v))
+		}
 	case reflect.Struct:
-		return graphql.NewNonNull(s.builtInputs[getKey(t)])
-	}
-	if sc, ok := s.isScalar(t); ok {
-		return graphql.NewNonNull(sc)
+		if v, ok := s.builtInputs[s.typeName(t)]; ok {
+			return graphql.NewNonNull(v)
+		}
 	}
 
-	panic("Invalid input type")
+	return nil
 }
 
 func (s *SchemaBuilder) getResolverOutputObjectFromType(t reflect.Type) (graphql.Output, reflect.Type) {
-
-	return s.builtOutputs[getKey(t)], t
+	if v, ok := s.builtOutputs[s.typeName(t)]; ok {
+		return v, t
+	}
+	return nil, t
 }
 
+// RegisterScalar registers a scalar for every Go type named key, whatever
+// its package. Prefer RegisterScalarFor, which matches a single type.
 func (s *SchemaBuilder) RegisterScalar(key string, sType *graphql.Scalar) {
-	s.checkScalars(key)
+	if !s.checkScalars(key) {
+		return
+	}
 	s.scalars[key] = sType
 }
 
+// RegisterScalarFor registers a scalar for the Go type t, pointers to t map to
+// it as well, e.g. RegisterScalarFor(reflect.TypeOf(uuid.UUID{}), UUIDScalar)
+func (s *SchemaBuilder) RegisterScalarFor(t reflect.Type, sType *graphql.Scalar) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		s.addErrorf("", "", "Scalar %s must be registered for a type", sType.Name())
+		return
+	}
+	if s.scalarTypes == nil {
+		s.scalarTypes = make(map[reflect.Type]*graphql.Scalar)
+	}
+	if _, ok := s.scalarTypes[t]; ok {
+		s.addErrorf(sType.Name(), "", "Scalar for type %s aready exists", t)
+		return
+	}
+	s.scalarTypes[t] = sType
+}
+
+// RegisterEnum registers a GraphQL enum for a Go named type. The values map
+// holds GraphQL value names as keys and the Go constants as values, all of the
+// same named type, e.g. map[string]interface{}{"OPEN": StatusOpen}.
+func (s *SchemaBuilder) RegisterEnum(name string, values map[string]interface{}) {
+	if len(values) == 0 {
+		s.addErrorf(name, "", "Enum %s must have at least one value", name)
+		return
+	}
+
+	var t reflect.Type
+	enumValues := graphql.EnumValueConfigMap{}
+	for k, v := range values {
+		vt := reflect.TypeOf(v)
+		if t == nil {
+			t = vt
+		} else if vt != t {
+			s.addError(&BuildError{
+				Object:    name,
+				Field:     k,
+				Signature: fmt.Sprint(vt),
+				Expected:  t.String(),
+				Message:   fmt.Sprintf("Enum %s value %s has type %s, expected %s", name, k, vt, t),
+			})
+			return
+		}
+		enumValues[k] = &graphql.EnumValueConfig{Value: v}
+	}
+
+	if !s.checkEnums(name, t) {
+		return
+	}
+	s.enums[t] = graphql.NewEnum(graphql.EnumConfig{
+		Name:   name,
+		Values: enumValues,
+	})
+}
+
+func (s *SchemaBuilder) checkEnums(name string, t reflect.Type) bool {
+	if s.enums == nil {
+		s.enums = make(map[reflect.Type]*graphql.Enum)
+	}
+	if _, ok := s.enums[t]; ok {
+		s.addErrorf(name, "", "Enum for type %s aready exists", t)
+		return false
+	}
+	return true
+}
+
 func (s *SchemaBuilder) SetDefaultScalars() {
 	if s.scalars == nil {
 		s.scalars = make(map[string]*graphql.Scalar)
@@ -716,39 +1228,253 @@ func (s *SchemaBuilder) SetDefaultScalars() {
 	}
 }
 
+// isScalar looks up the scalar of t by type identity first, scalars
+// registered by name match any type with that name and are a fallback. Named
+// types of the basic kinds fall back on the scalar of their kind.
 func (s *SchemaBuilder) isScalar(t reflect.Type) (*graphql.Scalar, bool) {
-	n := t.Name()
-	if v, ok := s.scalars[n]; ok {
+	if v, ok := s.typeScalar(t); ok {
+		return v, true
+	}
+	return s.kindScalar(t)
+}
+
+func (s *SchemaBuilder) typeScalar(t reflect.Type) (*graphql.Scalar, bool) {
+	if v, ok := s.scalarTypes[t]; ok {
+		return v, true
+	}
+	// the string mode of 64-bit integers overrides scalars registered by name
+	if s.int64AsString {
+		if v, ok := stringNumberScalars[t]; ok {
+			return v, true
+		}
+	}
+	if n := t.Name(); n != "" {
+		if v, ok := s.scalars[n]; ok {
+			return v, true
+		}
+	}
+	if v, ok := defaultScalars[t]; ok {
+		return v, true
+	}
+	if v, ok := numberScalars[t]; ok {
+		return v, true
+	}
+	if isJSONKind(t) {
+		return JSONScalar, true
+	}
+	return s.autoScalar(t)
+}
+
+// autoScalar generates a string scalar for named types which implement
+// encoding.TextMarshaler and encoding.TextUnmarshaler, or a JSON scalar for
+// json.Marshaler and json.Unmarshaler, so they are not walked as structs
+func (s *SchemaBuilder) autoScalar(t reflect.Type) (*graphql.Scalar, bool) {
+	if v, ok := s.autoScalars[t]; ok {
+		return v, true
+	}
+	if t.Name() == "" || t.Kind() == reflect.Ptr || t.Kind() == reflect.Interface {
+		return nil, false
+	}
+	if _, ok := s.enums[t]; ok {
+		return nil, false
+	}
+
+	var v *graphql.Scalar
+	name := s.typeName(t)
+	switch {
+	case isTextType(t):
+		v = NewTextScalar(name, t)
+	case isJSONType(t):
+		v = NewJSONMarshalerScalar(name, t)
+	default:
+		return nil, false
+	}
+	if s.autoScalars == nil {
+		s.autoScalars = make(map[reflect.Type]*graphql.Scalar)
+	}
+	s.autoScalars[t] = v
+	s.registerTypeName(name, t)
+	return v, true
+}
+
+func (s *SchemaBuilder) isEnum(t reflect.Type) (*graphql.Enum, bool) {
+	if v, ok := s.enums[t]; ok {
+		return v, true
+	}
+	return nil, false
+}
+
+// isLeaf reports whether t maps to a scalar or an enum
+func (s *SchemaBuilder) isLeaf(t reflect.Type) (graphql.Leaf, bool) {
+	if v, ok := s.isScalar(t); ok {
+		return v, true
+	}
+	if v, ok := s.isEnum(t); ok {
 		return v, true
 	}
 	return nil, false
 }
 
-func (s *SchemaBuilder) getFunc(fn interface{}) reflect.Value {
-	rf := reflect.ValueOf(fn)
-	return rf
+// isAbstract reports whether t is a Go interface registered as a GraphQL
+// interface or union
+func (s *SchemaBuilder) isAbstract(t reflect.Type) bool {
+	_, iface := s.interfaces[t]
+	_, union := s.unions[t]
+	return iface || union
 }
 
+func (s *SchemaBuilder) isAbstractName(name string) bool {
+	for _, io := range s.interfaces {
+		if io.Name == name {
+			return true
+		}
+	}
+	for _, uo := range s.unions {
+		if uo.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *SchemaBuilder) getAbstractType(t reflect.Type) (graphql.Output, bool) {
+	if io, ok := s.interfaces[t]; ok {
+		return s.builtIfaces[io.Name], true
+	}
+	if uo, ok := s.unions[t]; ok {
+		return s.builtUnions[uo.Name], true
+	}
+	return nil, false
+}
+
+// resolveObjectType maps the dynamic Go type of a value to its built object
+func (s *SchemaBuilder) resolveObjectType(value interface{}) *graphql.Object {
+	t := reflect.TypeOf(value)
+	if t == nil {
+		return nil
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if o, ok := s.builtOutputs[s.typeName(t)].(*graphql.Object); ok {
+		return o
+	}
+	log.Errorf("Cannot resolve object type for %s", t)
+	return nil
+}
+
+// validateCustomObjects checks that the resolvers of every custom object can
+// be attached to the built object
+func (s *SchemaBuilder) validateCustomObjects() []error {
+	errs := make([]error, 0)
+	for n, gm := range s.customObjects {
+		o := gm.(*Object)
+		t := reflect.TypeOf(o.Type)
+		if t == nil || t.Kind() != reflect.Struct {
+			errs = append(errs, &BuildError{
+				Object:    n,
+				Signature: fmt.Sprint(t),
+				Expected:  "struct",
+				Message:   fmt.Sprintf("Object %s must be a struct, got %v", n, t),
+			})
+			continue
+		}
+		for mn, m := range o.Methods {
+			ft := reflect.TypeOf(m.Fn)
+			if ft.NumIn() < 2 {
+				continue
+			}
+			if pt := ft.In(1); pt != t && pt != reflect.PtrTo(t) {
+				errs = append(errs, &BuildError{
+					Object:    n,
+					Field:     mn,
+					Signature: ft.String(),
+					Expected:  fmt.Sprintf("parent param %s or %s", t, reflect.PtrTo(t)),
+					Message:   fmt.Sprintf("Resolver %s of object %s cannot be attached, parent param is %s, expected %s or %s", mn, n, pt, t, reflect.PtrTo(t)),
+				})
+			}
+		}
+	}
+	return errs
+}
+
+func (s *SchemaBuilder) validateAbstractTypes() []error {
+	errs := make([]error, 0)
+	for _, io := range s.interfaces {
+		errs = append(errs, io.validate()...)
+	}
+	for _, uo := range s.unions {
+		errs = append(errs, uo.validate()...)
+	}
+	return errs
+}
+
+// implementationTypes returns the objects which implement an interface, they
+// must be passed to the schema explicitly as they may be unreachable from the root
+func (s *SchemaBuilder) implementationTypes() []graphql.Type {
+	types := make([]graphql.Type, 0)
+	for _, io := range s.interfaces {
+		for _, impl := range io.Implementations {
+			types = append(types, s.builtOutputs[s.typeName(reflect.TypeOf(impl))])
+		}
+	}
+	return types
+}
+
+// Build builds the schema, fields without a GraphQL mapping are skipped
 func (s *SchemaBuilder) Build() (graphql.Schema, error) {
+	schema, _, err := s.BuildWithReport()
+	return schema, err
+}
+
+// BuildWithReport builds the schema and reports the fields it skipped, the
+// mistakes found while registering and while building are returned together
+func (s *SchemaBuilder) BuildWithReport() (graphql.Schema, *BuildReport, error) {
+	errs := s.registrationErrors()
+	errs = append(errs, s.validateCustomObjects()...)
+	errs = append(errs, s.validateAbstractTypes()...)
+	errs = append(errs, s.validateFieldConfigs()...)
+	// errors recorded from here on are found while building
+	registered := len(s.errors)
+
 	s.SetDefaultScalars()
 	s.FindObjectsToBuild()
+	errs = append(errs, s.validateTypeNames()...)
 	s.CreateObjects()
 	s.CreateObjectsFields()
+	errs = append(errs, s.validateInterfaceFields()...)
 
 	mutation := s.buildMutation()
 	query := s.buildQuery()
 	subscription := s.buildSubscription()
 
-	schemaConfig := graphql.SchemaConfig{Query: query, Mutation: mutation, Subscription: subscription}
+	report := s.report()
+	for _, f := range report.SkippedFields {
+		logger.GetLogger().Warnf("Field %s of type %s is skipped: %s", f.Path, f.Type, f.Reason)
+	}
+	errs = append(errs, s.errors[registered:]...)
+	errs = append(errs, s.validateSkippedFields()...)
+	if err := newBuildErrors(errs); err != nil {
+		logger.GetLogger().Error("Error build Gomer schema", err)
+		return graphql.Schema{}, report, err
+	}
+
+	schemaConfig := graphql.SchemaConfig{
+		Query:        query,
+		Mutation:     mutation,
+		Subscription: subscription,
+		Types:        s.implementationTypes(),
+		Extensions:   []graphql.Extension{variablesExtension{}},
+	}
 	schema, err := graphql.NewSchema(schemaConfig)
 
 	if err != nil {
 		logger.GetLogger().Error("Error build Gomer schema", err)
-		return schema, err
+		return schema, report, err
 	}
 	logger.GetLogger().Infoln("Gomer schema build successfully")
 
-	return schema, err
+	return schema, report, err
 }
 
 type FieldResolveFn func() (interface{}, error)
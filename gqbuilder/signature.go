@@ -0,0 +1,130 @@
+package gqbuilder
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	channelType = reflect.TypeOf((chan interface{})(nil))
+)
+
+// handlerKind is the param layout of a resolver or a subscription handler
+type handlerKind int
+
+const (
+	handlerCtx handlerKind = iota
+	handlerCtxArgs
+	handlerCtxParent
+	handlerCtxParentArgs
+	handlerSubscription
+	handlerSubscriptionArgs
+)
+
+const (
+	rootResolverSignature   = "func(context.Context[, args struct]) (T, error)"
+	objectResolverSignature = "func(context.Context[, parent T or *T[, args struct]]) (T, error)"
+	subscriptionSignature   = "func(context.Context, chan interface{}[, args struct])"
+)
+
+// classifyResolver returns the param layout of a field resolver, resolvers of
+// root objects have no parent param
+func classifyResolver(fn interface{}, hasParent bool) (handlerKind, error) {
+	ft := reflect.TypeOf(fn)
+	if ft == nil || ft.Kind() != reflect.Func {
+		return 0, fmt.Errorf("handler must be a func, got %v", ft)
+	}
+	if ft.IsVariadic() {
+		return 0, fmt.Errorf("handler must not be variadic")
+	}
+	if ft.NumOut() != 2 {
+		return 0, fmt.Errorf("handler must return a result and an error, got %d results", ft.NumOut())
+	}
+	if ft.Out(1) != errorType {
+		return 0, fmt.Errorf("second result must be error, got %s", ft.Out(1))
+	}
+	if err := checkContextParam(ft); err != nil {
+		return 0, err
+	}
+
+	if !hasParent {
+		switch ft.NumIn() {
+		case 1:
+			return handlerCtx, nil
+		case 2:
+			return handlerCtxArgs, checkArgsParam(ft, 1)
+		}
+		return 0, fmt.Errorf("handler must have 1 or 2 params, got %d", ft.NumIn())
+	}
+
+	switch ft.NumIn() {
+	case 1:
+		return handlerCtx, nil
+	case 2:
+		return handlerCtxParent, checkParentParam(ft)
+	case 3:
+		if err := checkParentParam(ft); err != nil {
+			return 0, err
+		}
+		return handlerCtxParentArgs, checkArgsParam(ft, 2)
+	}
+	return 0, fmt.Errorf("handler must have from 1 to 3 params, got %d", ft.NumIn())
+}
+
+// classifySubscription returns the param layout of a subscription handler
+func classifySubscription(fn interface{}) (handlerKind, error) {
+	ft := reflect.TypeOf(fn)
+	if ft == nil || ft.Kind() != reflect.Func {
+		return 0, fmt.Errorf("handler must be a func, got %v", ft)
+	}
+	if ft.IsVariadic() {
+		return 0, fmt.Errorf("handler must not be variadic")
+	}
+	if ft.NumOut() != 0 {
+		return 0, fmt.Errorf("handler must not return results, got %d", ft.NumOut())
+	}
+	if err := checkContextParam(ft); err != nil {
+		return 0, err
+	}
+	if ft.NumIn() < 2 || ft.NumIn() > 3 {
+		return 0, fmt.Errorf("handler must have 2 or 3 params, got %d", ft.NumIn())
+	}
+	if !channelType.AssignableTo(ft.In(1)) {
+		return 0, fmt.Errorf("second param must be chan interface{}, got %s", ft.In(1))
+	}
+	if ft.NumIn() == 2 {
+		return handlerSubscription, nil
+	}
+	return handlerSubscriptionArgs, checkArgsParam(ft, 2)
+}
+
+func checkContextParam(ft reflect.Type) error {
+	if ft.NumIn() == 0 || ft.In(0) != contextType {
+		if ft.NumIn() == 0 {
+			return fmt.Errorf("first param must be context.Context, got no params")
+		}
+		return fmt.Errorf("first param must be context.Context, got %s", ft.In(0))
+	}
+	return nil
+}
+
+func checkParentParam(ft reflect.Type) error {
+	pt := ft.In(1)
+	if pt.Kind() == reflect.Ptr {
+		pt = pt.Elem()
+	}
+	if pt.Kind() != reflect.Struct {
+		return fmt.Errorf("parent param must be a struct or a pointer to a struct, got %s", ft.In(1))
+	}
+	return nil
+}
+
+func checkArgsParam(ft reflect.Type, pos int) error {
+	if ft.In(pos).Kind() != reflect.Struct {
+		return fmt.Errorf("args param must be a struct, got %s", ft.In(pos))
+	}
+	return nil
+}
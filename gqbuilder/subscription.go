@@ -1,7 +1,8 @@
 package gqbuilder
 
 import (
-	log "github.com/sirupsen/logrus"
+	"fmt"
+	"reflect"
 )
 
 type SubscriptionObject struct {
@@ -11,33 +12,58 @@ type SubscriptionObject struct {
 	Resolver    interface{}
 	Args        interface{}
 	Methods     map[string]*SubscriptionMethod
+	errorRecorder
 }
 
 func (s *SubscriptionObject) GetType() interface{} {
 	return s.Type
 }
 
-func (s *SubscriptionObject) FieldSubscription(name string, output interface{}, handler interface{}) {
-	s.checkMethods(name)
-
-	s.Methods[name] = &SubscriptionMethod{
+// FieldSubscription registers a subscription field and returns its handle,
+// which configures the generated field like the handle of FieldResolver
+func (s *SubscriptionObject) FieldSubscription(name string, output interface{}, handler interface{}) *SubscriptionMethod {
+	m := &SubscriptionMethod{
 		Name:   name,
 		Output: output,
 		Fn:     handler,
 	}
+	if !s.checkMethods(name) || !s.checkSignature(name, handler) {
+		return m
+	}
+	s.Methods[name] = m
+	return m
+}
+
+// checkSignature records an error when the handler does not match one of the
+// supported subscription signatures
+func (s *SubscriptionObject) checkSignature(name string, handler interface{}) bool {
+	if _, err := classifySubscription(handler); err != nil {
+		s.addError(&BuildError{
+			Object:    s.Name,
+			Field:     name,
+			Signature: fmt.Sprint(reflect.TypeOf(handler)),
+			Expected:  subscriptionSignature,
+			Message:   fmt.Sprintf("Subscription %s has invalid signature %v: %s, expected %s", name, reflect.TypeOf(handler), err, subscriptionSignature),
+		})
+		return false
+	}
+	return true
 }
 
-func (s *SubscriptionObject) checkMethods(name string) {
+func (s *SubscriptionObject) checkMethods(name string) bool {
 	if s.Methods == nil {
 		s.Methods = make(map[string]*SubscriptionMethod)
 	}
 
 	if _, ok := s.Methods[name]; ok {
-		log.Panicf("Func with name %s aready exists", name)
+		s.addErrorf(s.Name, name, "Func with name %s aready exists", name)
+		return false
 	}
+	return true
 }
 
 type SubscriptionMethod struct {
+	FieldConfig
 	Name   string
 	Output interface{}
 	Fn     interface{}
@@ -1,6 +1,8 @@
 package gqbuilder
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/graphql/language/ast"
@@ -11,6 +13,7 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
 )
 
 func hashFromArr(fMap map[string]string) string {
@@ -41,30 +44,169 @@ func getKey(t reflect.Type) string {
 	return fmt.Sprintf("%s", nk)
 }
 
-func getKeyWithHash(t reflect.Type, ig map[string]string) string {
-	key := getKey(t)
-	hashStr := hashFromArr(ig)
-	return fmt.Sprintf("%s%s", key, hashStr)
-}
-
 func getFieldName(name string) string {
 	return strcase.ToSnake(name)
 }
 
-func getArgs(fun reflect.Type) (reflect.Type, int, bool) {
-	if fun.NumIn() == 3 {
-		pos := 2
-		return fun.In(pos), pos, true
-	} else if fun.NumIn() == 2 {
-		pos := 1
-		t := fun.In(pos)
-		if t.Kind() == reflect.Chan {
-			return nil, 0, false
+// structFieldName returns the GraphQL name of a struct field, the name tag
+// param overrides the name derived by the naming strategy
+func structFieldName(f reflect.StructField, naming FieldNamingFn) string {
+	if n, ok := findGomerTags(f).ParamExist("name"); ok && n != "" {
+		return n
+	}
+	if naming != nil {
+		return naming(f)
+	}
+	return SnakeFieldNaming(f)
+}
+
+// isSkippedField reports whether a struct field is left out of the schema,
+// blank fields and fields tagged with gomer:"-" are skipped
+func isSkippedField(f reflect.StructField) bool {
+	if isBlankField(f) {
+		return true
+	}
+	_, ok := findGomerTags(f).ParamExist("-")
+	return ok
+}
+
+// structFieldResolver reads the field of the struct it was generated from by
+// its index, sources of other types fall back to the default resolver
+func structFieldResolver(owner reflect.Type, sf reflect.StructField, convert func(reflect.Value) reflect.Value) graphql.FieldResolveFn {
+	index := sf.Index
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		v := reflect.Indirect(reflect.ValueOf(p.Source))
+		if !v.IsValid() {
+			return nil, nil
+		}
+		if v.Type() != owner {
+			return graphql.DefaultResolveFn(p)
+		}
+		fv, ok := fieldByIndex(v, index)
+		if !ok {
+			return nil, nil
+		}
+		if convert != nil {
+			return convert(fv).Interface(), nil
+		}
+		return fv.Interface(), nil
+	}
+}
+
+// structFields returns the fields of a struct with the fields of embedded
+// structs promoted, following the Go shadowing rules. Embedded structs with
+// a name tag param are kept as nested fields.
+func structFields(t reflect.Type) []reflect.StructField {
+	fields := make([]reflect.StructField, 0, t.NumField())
+	for _, f := range reflect.VisibleFields(t) {
+		if isSkippedField(f) || isEmbeddedStruct(f) || !isPromoted(t, f.Index) {
+			continue
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func isEmbeddedStruct(f reflect.StructField) bool {
+	if !f.Anonymous {
+		return false
+	}
+	if _, ok := findGomerTags(f).ParamExist("name"); ok {
+		return false
+	}
+	t := f.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// isPromoted reports whether every struct on the index path is flattened
+func isPromoted(t reflect.Type, index []int) bool {
+	for i := 1; i < len(index); i++ {
+		f := t.FieldByIndex(index[:i])
+		if isSkippedField(f) || !isEmbeddedStruct(f) {
+			return false
+		}
+	}
+	return true
+}
+
+// isPromotedThroughPointer reports whether a promoted field is reached
+// through an embedded pointer, such fields are missing when it is nil
+func isPromotedThroughPointer(t reflect.Type, index []int) bool {
+	for i := 1; i < len(index); i++ {
+		if t.FieldByIndex(index[:i]).Type.Kind() == reflect.Ptr {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex which reports nil embedded
+// pointers instead of panicking
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// settableFieldByIndex returns the field on the index path, nil embedded
+// pointers are allocated on the way
+func settableFieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
 		}
+		v = v.Field(x)
+	}
+	return v, v.CanSet()
+}
+
+// getArgs returns the args type of a handler and its position. Handlers with
+// a parent take it, or the subscription channel, right after the context.
+func getArgs(fun reflect.Type, hasParent bool) (reflect.Type, int, bool) {
+	pos := 1
+	if hasParent {
+		pos = 2
+	}
+	if fun.NumIn() <= pos {
+		return nil, 0, false
+	}
+	return fun.In(pos), pos, true
+}
 
-		return fun.In(pos), pos, true
+// getParentValue converts a resolved source to the parent param type of a
+// field resolver, taking or dereferencing the pointer when needed
+func getParentValue(source interface{}, t reflect.Type) reflect.Value {
+	if source == nil {
+		return reflect.New(t).Elem()
 	}
-	return nil, 0, false
+	v := reflect.ValueOf(source)
+	switch {
+	case v.Type() == t:
+		return v
+	case t.Kind() == reflect.Ptr && v.Type() == t.Elem():
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(v)
+		return ptr
+	case v.Kind() == reflect.Ptr && v.Type().Elem() == t:
+		return v.Elem()
+	}
+	return v
 }
 
 func getArgumentValues(
@@ -185,27 +327,129 @@ func isNullish(src interface{}) bool {
 	return false
 }
 
-func getFieldDef(schema graphql.Schema, parentType *graphql.Object, fieldName string) *graphql.FieldDefinition {
+// fieldsType is an object or an interface which declares fields
+type fieldsType interface {
+	Name() string
+	Fields() graphql.FieldDefinitionMap
+}
+
+func getFieldDef(schema graphql.Schema, parentType graphql.Composite, fieldName string) *graphql.FieldDefinition {
 
 	if parentType == nil {
 		return nil
 	}
 
-	if fieldName == graphql.SchemaMetaFieldDef.Name &&
-		schema.QueryType() == parentType {
+	isQuery := parentType == graphql.Composite(schema.QueryType())
+	if fieldName == graphql.SchemaMetaFieldDef.Name && isQuery {
 		return graphql.SchemaMetaFieldDef
 	}
-	if fieldName == graphql.TypeMetaFieldDef.Name &&
-		schema.QueryType() == parentType {
+	if fieldName == graphql.TypeMetaFieldDef.Name && isQuery {
 		return graphql.TypeMetaFieldDef
 	}
 	if fieldName == graphql.TypeNameMetaFieldDef.Name {
 		return graphql.TypeNameMetaFieldDef
 	}
-	return parentType.Fields()[fieldName]
+	if ft, ok := parentType.(fieldsType); ok {
+		return ft.Fields()[fieldName]
+	}
+	return nil
+}
+
+// selectionParser builds the selection tree of a query, fragments keeps the
+// names of the fragments expanded on the current path to stop on cycles
+type selectionParser struct {
+	params    graphql.ResolveParams
+	argsMap   map[string]map[string]interface{}
+	decoders  *argsDecoders
+	fragments map[string]bool
+	errs      []error
 }
 
-func parseSelection(f ast.Selection, parentType *graphql.Object, p graphql.ResolveParams, argsMap map[string]map[string]interface{}) *Selection {
+func (sp *selectionParser) errorf(format string, args ...interface{}) {
+	sp.errs = append(sp.errs, fmt.Errorf(format, args...))
+}
+
+// err returns the errors met while parsing as a single error
+func (sp *selectionParser) err() error {
+	if len(sp.errs) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(sp.errs))
+	for i, e := range sp.errs {
+		msgs[i] = e.Error()
+	}
+	return errors.New(strings.Join(msgs, "\n"))
+}
+
+func newSelectionParser(p graphql.ResolveParams, argsMap map[string]map[string]interface{}, decoders *argsDecoders) *selectionParser {
+	if decoders == nil {
+		decoders = newArgsDecoders(nil)
+	}
+	return &selectionParser{
+		params:    p,
+		argsMap:   argsMap,
+		decoders:  decoders,
+		fragments: make(map[string]bool),
+	}
+}
+
+func (sp *selectionParser) parseSelectionSet(set *ast.SelectionSet, parentType graphql.Composite) []*Selection {
+	selections := make([]*Selection, 0)
+	for _, s := range set.Selections {
+		if !sp.shouldInclude(getDirectives(s)) {
+			continue
+		}
+		selections = append(selections, sp.parseSelection(s, parentType)...)
+	}
+	return selections
+}
+
+func getDirectives(s ast.Selection) []*ast.Directive {
+	switch v := s.(type) {
+	case *ast.Field:
+		return v.Directives
+	case *ast.InlineFragment:
+		return v.Directives
+	case *ast.FragmentSpread:
+		return v.Directives
+	}
+	return nil
+}
+
+// shouldInclude evaluates the @skip and @include directives of a selection
+func (sp *selectionParser) shouldInclude(directives []*ast.Directive) bool {
+	for _, d := range directives {
+		if d.Name == nil {
+			continue
+		}
+		switch d.Name.Value {
+		case graphql.SkipDirective.Name:
+			if sp.directiveCondition(d) {
+				return false
+			}
+		case graphql.IncludeDirective.Name:
+			if !sp.directiveCondition(d) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (sp *selectionParser) directiveCondition(d *ast.Directive) bool {
+	for _, a := range d.Arguments {
+		if a.Name != nil && a.Name.Value == "if" {
+			v, _ := valueFromAST(a.Value, graphql.Boolean, sp.params.Info.VariableValues).(bool)
+			return v
+		}
+	}
+	return false
+}
+
+// parseSelection returns the selections for a field or the flattened
+// selections of a fragment, fragment fields keep their type condition
+func (sp *selectionParser) parseSelection(f ast.Selection, parentType graphql.Composite) []*Selection {
+	p := sp.params
 	switch v := f.(type) {
 	case *ast.Field:
 		var sel *Selection
@@ -215,128 +459,228 @@ func parseSelection(f ast.Selection, parentType *graphql.Object, p graphql.Resol
 		sel = &Selection{
 			Name: fieldDef.Name,
 		}
-
-		if v.SelectionSet == nil {
-
-			return sel
+		if v.Alias != nil {
+			sel.Alias = v.Alias.Value
 		}
 
-		argsObject := argsMap[parentType.Name()][fieldDef.Name]
+		argsObject := sp.argsMap[parentType.Name()][fieldDef.Name]
 		if argsObject != nil {
 			parsedArgs := getArgumentValues(fieldDef.Args, v.Arguments, p.Info.VariableValues)
-			args = ReflectStructRecursive(reflect.TypeOf(argsObject), parsedArgs).Interface()
+			if v, err := sp.decoders.decoder(reflect.TypeOf(argsObject))(parsedArgs); err != nil {
+				sp.errorf("Cannot decode args of field %s, %s", fieldDef.Name, err)
+			} else {
+				args = v.Interface()
+			}
 		}
+		sel.Args = args
 
-		selections := make([]*Selection, 0)
+		if v.SelectionSet == nil {
 
-		for _, s := range v.SelectionSet.Selections {
-			fieldObject := getFieldObject(fieldDef.Type)
-			ps := parseSelection(s, fieldObject, p, argsMap)
-			selections = append(selections, ps)
+			return []*Selection{sel}
 		}
 
-		sel.Args = args
+		fieldObject, err := getFieldObject(fieldDef.Type)
+		if err != nil {
+			sp.errorf("Cannot parse selection of field %s, %s", fieldDef.Name, err)
+			return []*Selection{sel}
+		}
 		sel.SelectionSet = &SelectionSet{
-			Selections: selections,
+			Selections: sp.parseSelectionSet(v.SelectionSet, fieldObject),
+		}
+
+		return []*Selection{sel}
+	case *ast.InlineFragment:
+		return sp.parseFragment(v.TypeCondition, v.SelectionSet, parentType)
+	case *ast.FragmentSpread:
+		name := v.Name.Value
+		fd, ok := p.Info.Fragments[name].(*ast.FragmentDefinition)
+		if !ok {
+			log.Errorf("Fragment %s is not found", name)
+			return nil
 		}
+		if sp.fragments[name] {
+			log.Errorf("Fragment %s spreads itself", name)
+			return nil
+		}
+
+		sp.fragments[name] = true
+		defer delete(sp.fragments, name)
 
-		return sel
+		return sp.parseFragment(fd.TypeCondition, fd.SelectionSet, parentType)
 	default:
-		log.Panicf("Invalid type %s", v)
+		sp.errorf("Invalid selection type %T", v)
 	}
 	return nil
 }
 
-func ReflectStructFieldRecursive(fName string, t reflect.Type, param interface{}) reflect.Value {
-	v := reflect.New(t).Elem()
-
-	switch t.Kind() {
-	case reflect.Ptr:
-		log.Tracef("Reflect Ptr FieldName: %s, Type: %s ", fName, t.String())
-		rs := ReflectStructFieldRecursive(fName, t.Elem(), param)
-		ptr := reflect.New(t.Elem())
-		ptr.Elem().Set(rs)
-		v.Set(ptr)
-	case reflect.Struct:
-		log.Tracef("Reflect Struct FieldName: %s Type: %s", fName, t.String())
-
-		if reflect.TypeOf(param) == t {
-			v.Set(reflect.ValueOf(param))
-		} else {
-			rs := ReflectStructRecursive(t, param)
-			v.Set(rs)
-		}
-	case reflect.Slice:
-		log.Tracef("Reflect Struct FieldName: %s Type: %s", fName, t.String())
-		if reflect.TypeOf(param) == t {
-			v.Set(reflect.ValueOf(param))
-		} else {
-			slice := reflect.MakeSlice(t, 0, 5)
-			for _, ai := range param.([]interface{}) {
-				var item reflect.Value
-				if n, ok := ai.(map[string]interface{}); ok {
-					item = ReflectStructFieldRecursive(fName, t.Elem(), n)
-
-				} else {
-					ts := t.Elem().String()
-					log.Traceln(ts)
-					item = ReflectStructFieldRecursive(fName, t.Elem(), ai)
-				}
-
-				slice = reflect.Append(slice, item)
-			}
-			v.Set(slice)
+func (sp *selectionParser) parseFragment(tc *ast.Named, set *ast.SelectionSet, parentType graphql.Composite) []*Selection {
+	fragmentType := parentType
+	typeCondition := ""
+	if tc != nil {
+		typeCondition = tc.Name.Value
+		if t, ok := sp.params.Info.Schema.Type(typeCondition).(graphql.Composite); ok {
+			fragmentType = t
 		}
-	default:
-		log.Tracef("Reflect Default FieldName: %s Type: %s", fName, t.String())
-		if param != nil {
-			v.Set(reflect.ValueOf(param))
+	}
+
+	selections := sp.parseSelectionSet(set, fragmentType)
+	for _, sel := range selections {
+		if sel.TypeCondition == "" {
+			sel.TypeCondition = typeCondition
 		}
 	}
-	log.Tracef("Reflect Return Value %s FieldName: %s Type: %s", v.Interface(), fName, t.String())
+	return selections
+}
+
+// DecodeArgs decodes args into a value of type t, the fields are looked up by
+// their default snake_case names. It returns a *CoercionError when a value
+// cannot be converted to the type of its field.
+func DecodeArgs(t reflect.Type, param interface{}) (reflect.Value, error) {
+	return newArgsDecoders(nil).decoder(t)(param)
+}
+
+// ReflectStructFieldRecursive decodes an arg value into a value of type t,
+// it returns the zero value when the value cannot be decoded
+func ReflectStructFieldRecursive(fName string, t reflect.Type, param interface{}) reflect.Value {
+	v, err := DecodeArgs(t, param)
+	if err != nil {
+		log.Errorf("Cannot decode field %s, %s", fName, withPath(err, fName))
+		return reflect.Zero(t)
+	}
 	return v
 }
 
+// ReflectStructRecursive decodes args into a value of type t with the default
+// snake_case names, it returns the zero value when the args cannot be decoded
 func ReflectStructRecursive(t reflect.Type, param interface{}) reflect.Value {
-	val := reflect.New(t).Elem()
-	for i := 0; i < t.NumField(); i++ {
-		f := t.Field(i)
-		fieldName := getFieldName(f.Name)
-		if n, ok := param.(map[string]interface{}); ok {
-			if np, ok := n[fieldName]; ok {
-				rs := ReflectStructFieldRecursive(fieldName, f.Type, np)
-				val.Field(i).Set(rs)
+	v, err := DecodeArgs(t, param)
+	if err != nil {
+		log.Errorf("Cannot decode args, %s", err)
+		return reflect.Zero(t)
+	}
+	return v
+}
+
+// ParseSelections returns the selections of the operation, args are decoded
+// with the default snake_case names. On parse errors, such as args which
+// cannot be decoded, the selections which could be parsed are returned with
+// the error.
+func ParseSelections(p graphql.ResolveParams, argsMap map[string]map[string]interface{}) ([]*Selection, error) {
+	return parseSelections(p, argsMap, nil)
+}
+
+// ParseFieldSelection returns the selection of the field being resolved,
+// built from p.Info.FieldASTs. Occurrences merged under one response key
+// contribute their sub-selections to the same Selection. Args are decoded with
+// the default snake_case names, on parse errors the part which could be
+// parsed is returned with the error.
+func ParseFieldSelection(p graphql.ResolveParams, argsMap map[string]map[string]interface{}) (*Selection, error) {
+	return parseFieldSelection(p, argsMap, nil)
+}
+
+// DecodeArgs decodes args into a value of type t, the fields are looked up by
+// the names of the builder field naming
+func (s *SchemaBuilder) DecodeArgs(t reflect.Type, param interface{}) (reflect.Value, error) {
+	return s.getDecoders().decoder(t)(param)
+}
+
+// ReflectStructRecursive decodes args into a value of type t with the builder
+// field naming, it returns the zero value when the args cannot be decoded
+func (s *SchemaBuilder) ReflectStructRecursive(t reflect.Type, param interface{}) reflect.Value {
+	v, err := s.DecodeArgs(t, param)
+	if err != nil {
+		log.Errorf("Cannot decode args, %s", err)
+		return reflect.Zero(t)
+	}
+	return v
+}
+
+// ParseSelections returns the selections of the operation with the args of
+// the resolvers of the built schema, decoded with the builder field naming
+func (s *SchemaBuilder) ParseSelections(p graphql.ResolveParams) ([]*Selection, error) {
+	return parseSelections(p, s.argsMap, s.getDecoders())
+}
+
+// ParseFieldSelection returns the selection of the field being resolved with
+// the args of the resolvers of the built schema, decoded with the builder
+// field naming
+func (s *SchemaBuilder) ParseFieldSelection(p graphql.ResolveParams) (*Selection, error) {
+	return parseFieldSelection(p, s.argsMap, s.getDecoders())
+}
+
+func parseSelections(p graphql.ResolveParams, argsMap map[string]map[string]interface{}, decoders *argsDecoders) ([]*Selection, error) {
+	od := p.Info.Operation.(*ast.OperationDefinition)
+	sp := newSelectionParser(p, argsMap, decoders)
+	selections := sp.parseSelectionSet(od.GetSelectionSet(), p.Info.ParentType.(*graphql.Object))
+	return selections, sp.err()
+}
+
+func parseFieldSelection(p graphql.ResolveParams, argsMap map[string]map[string]interface{}, decoders *argsDecoders) (*Selection, error) {
+	sp := newSelectionParser(p, argsMap, decoders)
+	var sel *Selection
+	for _, f := range p.Info.FieldASTs {
+		for _, fs := range sp.parseSelection(f, p.Info.ParentType) {
+			if sel == nil {
+				sel = fs
+			} else if fs.SelectionSet != nil {
+				sel.SelectionSet.Selections = append(sel.SelectionSet.Selections, fs.SelectionSet.Selections...)
 			}
-		} else {
-			rs := ReflectStructFieldRecursive(fieldName, f.Type, param)
-			val.Field(i).Set(rs)
 		}
 	}
+	return sel, sp.err()
+}
 
-	return val
+type selectionKey struct{}
+
+// lazySelection parses the field selection on the first access, so resolvers
+// which never read it do not pay for it
+type lazySelection struct {
+	once      sync.Once
+	params    graphql.ResolveParams
+	argsMap   map[string]map[string]interface{}
+	decoders  *argsDecoders
+	selection *Selection
+	err       error
 }
 
-func ParseSelections(p graphql.ResolveParams, argsMap map[string]map[string]interface{}) []*Selection {
-	selections := make([]*Selection, 0)
-	od := p.Info.Operation.(*ast.OperationDefinition)
-	s := od.GetSelectionSet().Selections
-	for _, f := range s {
-		selections = append(selections, parseSelection(f, p.Info.ParentType.(*graphql.Object), p, argsMap))
+func (ls *lazySelection) get() (*Selection, error) {
+	ls.once.Do(func() {
+		ls.selection, ls.err = parseFieldSelection(ls.params, ls.argsMap, ls.decoders)
+	})
+	return ls.selection, ls.err
+}
+
+func withSelection(ctx context.Context, p graphql.ResolveParams, argsMap map[string]map[string]interface{}, decoders *argsDecoders) context.Context {
+	return context.WithValue(ctx, selectionKey{}, &lazySelection{params: p, argsMap: argsMap, decoders: decoders})
+}
+
+// SelectionFromContext returns the selection of the field being resolved,
+// its SelectionSet holds the sub-fields requested below it. When the
+// selection cannot be parsed, e.g. the args of a sub-field cannot be decoded,
+// the part which could be parsed is returned with the error.
+func SelectionFromContext(ctx context.Context) (*Selection, error) {
+	if ls, ok := ctx.Value(selectionKey{}).(*lazySelection); ok {
+		return ls.get()
 	}
-	return selections
+	return nil, nil
 }
 
-func getFieldObject(f graphql.Type) *graphql.Object {
+// getFieldObject returns the object, interface or union type of a field with
+// a selection set
+func getFieldObject(f graphql.Type) (graphql.Composite, error) {
 	switch v := f.(type) {
 	case *graphql.List:
 		return getFieldObject(v.OfType)
 	case *graphql.NonNull:
 		return getFieldObject(v.OfType)
 	case *graphql.Object:
-		return v
+		return v, nil
+	case *graphql.Interface:
+		return v, nil
+	case *graphql.Union:
+		return v, nil
 	}
-	log.Panicf("Cannot get field object type")
-	return nil
+	return nil, fmt.Errorf("type %s has no fields", f)
 }
 
 func findGomerTags(t reflect.StructField) GomerTags {
@@ -346,11 +690,15 @@ func findGomerTags(t reflect.StructField) GomerTags {
 		tr := strings.TrimSpace(tag)
 		spl := strings.Split(tr, ";")
 		for _, v := range spl {
-			pspl := strings.Split(strings.TrimSpace(v), ":")
-			if len(pspl) < 2 {
+			// only the first colon separates the param name, values may contain colons
+			pspl := strings.SplitN(strings.TrimSpace(v), ":", 2)
+			if pspl[0] == "" {
 				log.Errorf("Invalid param %s", v)
+			} else if len(pspl) < 2 {
+				// flags such as required, nullable and - have no value
+				gomerTags[pspl[0]] = ""
 			} else {
-				gomerTags[pspl[0]] = pspl[1]
+				gomerTags[pspl[0]] = strings.TrimSpace(pspl[1])
 			}
 		}
 	}
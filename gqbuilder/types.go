@@ -14,10 +14,11 @@ type SelectionSet struct {
 }
 
 type Selection struct {
-	Name         string
-	Alias        string
-	Args         interface{}
-	SelectionSet *SelectionSet
+	Name          string
+	Alias         string
+	TypeCondition string
+	Args          interface{}
+	SelectionSet  *SelectionSet
 }
 
 type GomerInputObject struct {
@@ -42,6 +43,11 @@ func (gt GomerTags) GetParam(n string) string {
 
 }
 
+// GetOptionalParam returns the param value or an empty string when it is absent
+func (gt GomerTags) GetOptionalParam(n string) string {
+	return gt[n]
+}
+
 func (gt GomerTags) ParamExist(n string) (string, bool) {
 	if v, ok := gt[n]; ok {
 		return v, true
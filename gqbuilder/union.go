@@ -0,0 +1,44 @@
+package gqbuilder
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type UnionObject struct {
+	Name        string
+	Description string
+	Interface   reflect.Type
+	Types       []interface{}
+}
+
+// validate checks that every union member is a struct which implements the
+// marker interface
+func (s *UnionObject) validate() []error {
+	if len(s.Types) == 0 {
+		return []error{&BuildError{Object: s.Name, Message: fmt.Sprintf("Union %s has no member types", s.Name)}}
+	}
+
+	errs := make([]error, 0)
+	for _, m := range s.Types {
+		mt := reflect.TypeOf(m)
+		if mt == nil || mt.Kind() != reflect.Struct {
+			errs = append(errs, &BuildError{
+				Object:    s.Name,
+				Signature: fmt.Sprint(mt),
+				Expected:  "struct",
+				Message:   fmt.Sprintf("Union %s member must be a struct, got %v", s.Name, mt),
+			})
+			continue
+		}
+		if !mt.Implements(s.Interface) && !reflect.PtrTo(mt).Implements(s.Interface) {
+			errs = append(errs, &BuildError{
+				Object:    s.Name,
+				Signature: mt.String(),
+				Expected:  fmt.Sprintf("implementation of %s", s.Interface),
+				Message:   fmt.Sprintf("Type %s does not implement interface %s", mt, s.Interface),
+			})
+		}
+	}
+	return errs
+}
@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/mirogindev/gomer/gqbuilder"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type SKU struct {
+	prefix string
+	number int
+}
+
+func (s SKU) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%s-%d", s.prefix, s.number)), nil
+}
+
+func (s *SKU) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "%3s-%d", &s.prefix, &s.number)
+	return err
+}
+
+type Money struct {
+	cents    int64
+	currency string
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{"amount": float64(m.cents) / 100, "currency": m.currency})
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Amount   float64
+		Currency string
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	m.cents, m.currency = int64(v.Amount*100), v.Currency
+	return nil
+}
+
+type Product struct {
+	SKU     SKU
+	Related []*SKU
+	Price   *Money
+}
+
+type ProductInput struct {
+	SKU   SKU
+	Price Money
+}
+
+func buildProductSchema() *gqbuilder.SchemaBuilder {
+	builder := gqbuilder.GetBuilder()
+	query := builder.Query()
+	query.FieldResolver("product", func(ctx context.Context, args struct {
+		SKU SKU
+	}) (*Product, error) {
+		return &Product{
+			SKU:     args.SKU,
+			Related: []*SKU{{prefix: "REL", number: args.SKU.number + 1}},
+			Price:   &Money{cents: 1250, currency: "EUR"},
+		}, nil
+	})
+	mutation := builder.Mutation()
+	mutation.FieldResolver("product_insert", func(ctx context.Context, args struct {
+		Input ProductInput
+	}) (*Product, error) {
+		return &Product{SKU: args.Input.SKU, Price: &args.Input.Price}, nil
+	})
+	return builder
+}
+
+func TestTextMarshalerScalar(t *testing.T) {
+	schema, err := buildProductSchema().Build()
+	assert.Nil(t, err)
+	assert.IsType(t, &graphql.Scalar{}, schema.Type("SKU"))
+	assert.IsType(t, &graphql.Scalar{}, schema.Type("Money"))
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ product(sku: "ABC-41") { sku related price } }`})
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, map[string]interface{}{
+		"sku":     "ABC-41",
+		"related": []interface{}{"REL-42"},
+		"price":   map[string]interface{}{"amount": 12.5, "currency": "EUR"},
+	}, r.Data.(map[string]interface{})["product"])
+
+	r = graphql.Do(graphql.Params{Schema: schema, RequestString: `{ product(sku: "oops") { sku } }`})
+	assert.NotEmpty(t, r.Errors)
+}
+
+func TestJSONMarshalerScalar(t *testing.T) {
+	schema, err := buildProductSchema().Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `mutation {
+		product_insert(input: {sku: "XYZ-1", price: {amount: 3.5, currency: "USD"}}) { sku price }
+	}`})
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, map[string]interface{}{
+		"sku":   "XYZ-1",
+		"price": map[string]interface{}{"amount": 3.5, "currency": "USD"},
+	}, r.Data.(map[string]interface{})["product_insert"])
+
+	r = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `mutation($input: ProductInput!) { product_insert(input: $input) { price } }`,
+		VariableValues: map[string]interface{}{
+			"input": map[string]interface{}{"sku": "XYZ-2", "price": map[string]interface{}{"amount": 1, "currency": "USD"}},
+		},
+	})
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, map[string]interface{}{"amount": float64(1), "currency": "USD"},
+		r.Data.(map[string]interface{})["product_insert"].(map[string]interface{})["price"])
+}
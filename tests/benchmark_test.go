@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"testing"
+)
+
+const benchmarkTicketQuery = `
+	{
+		ticket(limit: 15, offset: 10, filter: { title: { eq: "ddd" }, id: { neq: 500 } }) {
+			title
+			tags(limit: 10, offset: 2) { title }
+		}
+	}
+`
+
+func BenchmarkTicketQuery(b *testing.B) {
+	schema, err := BuildTestSchema()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := graphql.Do(graphql.Params{Schema: schema, RequestString: benchmarkTicketQuery})
+		if len(r.Errors) > 0 {
+			b.Fatal(r.Errors)
+		}
+	}
+}
+
+// BenchmarkTicketQueryExecute leaves parsing out to measure the resolvers
+func BenchmarkTicketQueryExecute(b *testing.B) {
+	schema, err := BuildTestSchema()
+	if err != nil {
+		b.Fatal(err)
+	}
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(benchmarkTicketQuery)})})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := graphql.Execute(graphql.ExecuteParams{Schema: schema, AST: doc})
+		if len(r.Errors) > 0 {
+			b.Fatal(r.Errors)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/graphql-go/graphql"
+	"github.com/mirogindev/gomer/gqbuilder"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type coercionCode string
+
+type coercionLevel int
+
+func (l *coercionLevel) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return errors.New("unknown level")
+	}
+	return nil
+}
+
+type coercionPoint struct {
+	X, Y int
+}
+
+func (p *coercionPoint) UnmarshalJSON(data []byte) error {
+	var xy [2]int
+	if err := json.Unmarshal(data, &xy); err != nil {
+		return err
+	}
+	p.X, p.Y = xy[0], xy[1]
+	return nil
+}
+
+type coercionItem struct {
+	Count uint8
+}
+
+type coercionArgs struct {
+	Small  int8
+	Medium int32
+	Count  uint
+	Port   uint16
+	Ratio  float32
+	Code   coercionCode
+	Box    [2]int
+	Level  coercionLevel
+	Point  coercionPoint
+	Items  []coercionItem
+	Tags   []string
+}
+
+func TestCoerceArgs(t *testing.T) {
+	v, err := gqbuilder.DecodeArgs(reflect.TypeOf(coercionArgs{}), map[string]interface{}{
+		"small":  100,
+		"medium": int64(-5),
+		"count":  float64(42),
+		"port":   8080,
+		"ratio":  0.5,
+		"code":   "A1",
+		"box":    []interface{}{1, 2},
+		"level":  "HIGH",
+		"point":  []interface{}{3, 4},
+		"items":  []interface{}{map[string]interface{}{"count": 7}},
+		"tags":   "single",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, coercionArgs{
+		Small:  100,
+		Medium: -5,
+		Count:  42,
+		Port:   8080,
+		Ratio:  0.5,
+		Code:   "A1",
+		Box:    [2]int{1, 2},
+		Level:  2,
+		Point:  coercionPoint{X: 3, Y: 4},
+		Items:  []coercionItem{{Count: 7}},
+		Tags:   []string{"single"},
+	}, v.Interface())
+}
+
+func TestCoerceArgsErrors(t *testing.T) {
+	cases := []struct {
+		args map[string]interface{}
+		err  string
+	}{
+		{map[string]interface{}{"small": 300}, "Argument small value 300 cannot be used as int8: overflow"},
+		{map[string]interface{}{"count": -1}, "Argument count value -1 cannot be used as uint: overflow"},
+		{map[string]interface{}{"medium": 1.5}, "Argument medium value 1.5 cannot be used as int32: not an integer in range"},
+		{map[string]interface{}{"ratio": 1e300}, "Argument ratio value 1e+300 cannot be used as float32: overflow"},
+		{map[string]interface{}{"code": 1}, "Argument code value 1 cannot be used as tests.coercionCode: expected a string"},
+		{map[string]interface{}{"box": []interface{}{1}}, "Argument box value [1] cannot be used as [2]int: expected 2 items, got 1"},
+		{map[string]interface{}{"level": "medium"}, "Argument level value medium cannot be used as tests.coercionLevel: unknown level"},
+		{map[string]interface{}{"items": []interface{}{map[string]interface{}{"count": 1}, map[string]interface{}{"count": 256}}}, "Argument items[1].count value 256 cannot be used as uint8: overflow"},
+	}
+	for _, c := range cases {
+		_, err := gqbuilder.DecodeArgs(reflect.TypeOf(coercionArgs{}), c.args)
+		assert.EqualError(t, err, c.err)
+		assert.IsType(t, &gqbuilder.CoercionError{}, err)
+	}
+}
+
+func TestCoercionErrorInResolver(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+	builder.RegisterScalar("uint8", graphql.Int)
+
+	called := false
+	query := builder.Query()
+	query.FieldResolver("level", func(ctx context.Context, args struct {
+		Level uint8
+	}) (int, error) {
+		called = true
+		return int(args.Level), nil
+	})
+
+	schema, err := builder.Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ level(level: 7) }`})
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, map[string]interface{}{"level": 7}, r.Data)
+
+	called = false
+	r = graphql.Do(graphql.Params{Schema: schema, RequestString: `{ level(level: 300) }`})
+	assert.False(t, called)
+	assert.Len(t, r.Errors, 1)
+	assert.Equal(t, "Argument level value 300 cannot be used as uint8: overflow", r.Errors[0].Message)
+}
+
+type coercionFlag bool
+
+type coercionContact struct {
+	Email  coercionCode
+	Active coercionFlag
+	Box    [2]int
+	Emails [2]*coercionCode
+}
+
+func TestArraysAndNamedKindsInSchema(t *testing.T) {
+	var got struct {
+		Email  coercionCode
+		Active *coercionFlag
+		Box    [2]int
+	}
+	builder := gqbuilder.GetBuilder()
+	query := builder.Query()
+	query.FieldResolver("contact", func(ctx context.Context, args struct {
+		Email  coercionCode
+		Active *coercionFlag
+		Box    [2]int
+	}) (*coercionContact, error) {
+		got = args
+		return &coercionContact{Email: args.Email, Active: *args.Active, Box: args.Box, Emails: [2]*coercionCode{&args.Email, nil}}, nil
+	})
+	query.FieldResolver("codes", func(ctx context.Context) ([2]coercionCode, error) {
+		return [2]coercionCode{"A1", "B2"}, nil
+	})
+
+	schema, err := builder.Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `query($box: [Int!]!) {
+			contact(email: "a@b.c", active: true, box: $box) { email active box emails }
+			codes
+		}`,
+		VariableValues: map[string]interface{}{"box": []interface{}{1, 2}},
+	})
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, map[string]interface{}{
+		"contact": map[string]interface{}{
+			"email":  "a@b.c",
+			"active": true,
+			"box":    []interface{}{1, 2},
+			"emails": []interface{}{"a@b.c", nil},
+		},
+		"codes": []interface{}{"A1", "B2"},
+	}, r.Data)
+
+	assert.Equal(t, coercionCode("a@b.c"), got.Email)
+	assert.Equal(t, coercionFlag(true), *got.Active)
+	assert.Equal(t, [2]int{1, 2}, got.Box)
+
+	r = graphql.Do(graphql.Params{Schema: schema, RequestString: `{ contact(email: "a@b.c", box: [1]) { email } }`})
+	assert.Len(t, r.Errors, 1)
+	assert.Equal(t, "Argument box value [1] cannot be used as [2]int: expected 2 items, got 1", r.Errors[0].Message)
+}
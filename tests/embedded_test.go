@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"context"
+	"github.com/graphql-go/graphql"
+	"github.com/mirogindev/gomer/gqbuilder"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type BaseModel struct {
+	ID    int64
+	Title string
+}
+
+type Audit struct {
+	CreatedBy string
+}
+
+type embeddedArticle struct {
+	BaseModel
+	*Audit
+	Title string
+}
+
+type embeddedArticleInput struct {
+	BaseModel
+	*Audit
+}
+
+type embeddedNested struct {
+	Audit `gomer:"name:audit"`
+	Text  string
+}
+
+func buildEmbeddedSchema() *gqbuilder.SchemaBuilder {
+	builder := gqbuilder.GetBuilder()
+
+	query := builder.Query()
+	query.FieldResolver("articles", func(ctx context.Context) ([]*embeddedArticle, error) {
+		return []*embeddedArticle{
+			{BaseModel: BaseModel{ID: 1, Title: "Base"}, Audit: &Audit{CreatedBy: "Author"}, Title: "Article"},
+			{BaseModel: BaseModel{ID: 2}},
+		}, nil
+	})
+	query.FieldResolver("nested", func(ctx context.Context) (*embeddedNested, error) {
+		return &embeddedNested{Audit: Audit{CreatedBy: "Author"}, Text: "Text"}, nil
+	})
+
+	mutation := builder.Mutation()
+	mutation.FieldResolver("article_insert", func(ctx context.Context, args struct {
+		Input *embeddedArticleInput
+	}) (*embeddedArticle, error) {
+		return &embeddedArticle{BaseModel: args.Input.BaseModel, Audit: args.Input.Audit}, nil
+	})
+
+	return builder
+}
+
+func TestEmbeddedOutputFields(t *testing.T) {
+	schema, err := buildEmbeddedSchema().Build()
+	assert.Nil(t, err)
+
+	fields := schema.Type("embeddedArticle").(*graphql.Object).Fields()
+	assert.Contains(t, fields, "id")
+	assert.Contains(t, fields, "title")
+	assert.Equal(t, "String", fields["created_by"].Type.String())
+	assert.NotContains(t, fields, "base_model")
+	assert.Nil(t, schema.Type("BaseModel"))
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `{
+		articles { id title created_by }
+		nested { text audit { created_by } }
+	}`})
+	assert.Empty(t, r.Errors)
+	data := r.Data.(map[string]interface{})
+	articles := data["articles"].([]interface{})
+	assert.Equal(t, map[string]interface{}{"id": int64(1), "title": "Article", "created_by": "Author"}, articles[0])
+	assert.Nil(t, articles[1].(map[string]interface{})["created_by"])
+	assert.Equal(t, "Author", data["nested"].(map[string]interface{})["audit"].(map[string]interface{})["created_by"])
+}
+
+func TestEmbeddedInputFields(t *testing.T) {
+	schema, err := buildEmbeddedSchema().Build()
+	assert.Nil(t, err)
+
+	fields := schema.Type("embeddedArticleInput").(*graphql.InputObject).Fields()
+	assert.Contains(t, fields, "id")
+	assert.Contains(t, fields, "created_by")
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `mutation {
+		article_insert(input: {id: 3, title: "Base", created_by: "Author"}) { id created_by }
+	}`})
+	assert.Empty(t, r.Errors)
+	article := r.Data.(map[string]interface{})["article_insert"].(map[string]interface{})
+	assert.Equal(t, int64(3), article["id"])
+	assert.Equal(t, "Author", article["created_by"])
+}
+
+func TestEmbeddedReflectStruct(t *testing.T) {
+	params := map[string]interface{}{"id": int64(5), "title": "Base", "created_by": "Author"}
+	args := gqbuilder.ReflectStructRecursive(reflect.TypeOf(embeddedArticle{}), params).Interface().(embeddedArticle)
+
+	assert.Equal(t, int64(5), args.ID)
+	assert.Equal(t, "Base", args.Title)
+	assert.Equal(t, "", args.BaseModel.Title)
+	assert.Equal(t, "Author", args.CreatedBy)
+}
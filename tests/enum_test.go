@@ -0,0 +1,124 @@
+package tests
+
+import (
+	"context"
+	"github.com/graphql-go/graphql"
+	"github.com/mirogindev/gomer/gqbuilder"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type TicketStatus string
+
+const (
+	TicketStatusOpen   TicketStatus = "open"
+	TicketStatusClosed TicketStatus = "closed"
+)
+
+type StatusTicket struct {
+	ID     string
+	Title  string
+	Status TicketStatus
+}
+
+type StatusTicketInput struct {
+	Title  string
+	Status *TicketStatus
+}
+
+type StatusFilterInput struct {
+	Status []TicketStatus
+}
+
+func buildEnumSchema() *gqbuilder.SchemaBuilder {
+	builder := gqbuilder.GetBuilder()
+	builder.RegisterEnum("TicketStatus", map[string]interface{}{
+		"OPEN":   TicketStatusOpen,
+		"CLOSED": TicketStatusClosed,
+	})
+
+	query := builder.Query()
+	query.FieldResolver("ticket_by_status", func(ctx context.Context, args struct {
+		Status TicketStatus
+	}) ([]*StatusTicket, error) {
+		return []*StatusTicket{{ID: "1", Title: "Ticket1", Status: args.Status}}, nil
+	})
+
+	mutation := builder.Mutation()
+	mutation.FieldResolver("ticket_insert", func(ctx context.Context, args struct {
+		Input *StatusTicketInput
+	}) (*StatusTicket, error) {
+		ticket := &StatusTicket{ID: "1", Title: args.Input.Title, Status: TicketStatusOpen}
+		if args.Input.Status != nil {
+			ticket.Status = *args.Input.Status
+		}
+		return ticket, nil
+	})
+	return builder
+}
+
+func TestEnumOutputAndArgs(t *testing.T) {
+	schema, err := buildEnumSchema().Build()
+	assert.Nil(t, err)
+
+	query := `
+		{
+			ticket_by_status(status: CLOSED) { title, status }
+		}
+	`
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+	assert.Empty(t, r.Errors)
+
+	data := r.Data.(map[string]interface{})
+	tickets := data["ticket_by_status"].([]interface{})
+	assert.Equal(t, "CLOSED", tickets[0].(map[string]interface{})["status"])
+}
+
+func TestEnumInputObject(t *testing.T) {
+	schema, err := buildEnumSchema().Build()
+	assert.Nil(t, err)
+
+	query := `
+		mutation {
+			ticket_insert (input:{title:"t1", status: CLOSED}) { status }
+		}
+	`
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+	assert.Empty(t, r.Errors)
+
+	data := r.Data.(map[string]interface{})
+	assert.Equal(t, "CLOSED", data["ticket_insert"].(map[string]interface{})["status"])
+}
+
+func TestEnumVariables(t *testing.T) {
+	schema, err := buildEnumSchema().Build()
+	assert.Nil(t, err)
+
+	query := `
+		query($status: TicketStatus!) {
+			ticket_by_status(status: $status) { status }
+		}
+	`
+	r := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  query,
+		VariableValues: map[string]interface{}{"status": "OPEN"},
+	})
+	assert.Empty(t, r.Errors)
+
+	data := r.Data.(map[string]interface{})
+	tickets := data["ticket_by_status"].([]interface{})
+	assert.Equal(t, "OPEN", tickets[0].(map[string]interface{})["status"])
+}
+
+func TestEnumReflection(t *testing.T) {
+	params := map[string]interface{}{
+		"status": []interface{}{TicketStatusOpen, "closed"},
+	}
+
+	args := gqbuilder.ReflectStructRecursive(reflect.TypeOf(StatusFilterInput{}), params)
+	obj := args.Interface().(StatusFilterInput)
+
+	assert.Equal(t, []TicketStatus{TicketStatusOpen, TicketStatusClosed}, obj.Status)
+}
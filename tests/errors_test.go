@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"context"
+	"github.com/mirogindev/gomer/gqbuilder"
+	"github.com/mirogindev/gomer/test_uttils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBuildErrorsAggregated(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+
+	query := builder.Query()
+	query.FieldResolver("tags", func(ctx context.Context) ([]*test_uttils.Tag, error) {
+		return nil, nil
+	})
+	query.FieldResolver("tags", func(ctx context.Context) ([]*test_uttils.Tag, error) {
+		return nil, nil
+	}).Description("Duplicate")
+	builder.Query()
+
+	builder.RegisterEnum("Mixed", map[string]interface{}{"OPEN": TicketStatusOpen, "ONE": 1})
+	builder.Interface("Broken", test_uttils.Tag{}, FeedItemFields{})
+
+	tag := builder.Object("Tag", test_uttils.Tag{})
+	tag.FieldResolver("ticket_title", func(ctx context.Context, o *test_uttils.Ticket) (string, error) {
+		return o.Title, nil
+	})
+
+	_, err := builder.Build()
+	assert.NotNil(t, err)
+
+	errs, ok := err.(gqbuilder.BuildErrors)
+	assert.True(t, ok)
+	assert.Len(t, errs, 5)
+
+	msgs := make([]string, 0)
+	for _, e := range errs {
+		msgs = append(msgs, e.Error())
+	}
+	assert.Contains(t, msgs, "Func with name tags aready exists")
+	assert.Contains(t, msgs, "Resolver with name Query aready exists")
+	assert.Contains(t, msgs, "Interface Broken must be passed as a nil pointer to a Go interface, got test_uttils.Tag")
+
+	for _, e := range errs {
+		be := e.(*gqbuilder.BuildError)
+		switch be.Object {
+		case "Tag":
+			assert.Equal(t, "ticket_title", be.Field)
+			assert.Equal(t, "func(context.Context, *test_uttils.Ticket) (string, error)", be.Signature)
+			assert.Equal(t, "parent param test_uttils.Tag or *test_uttils.Tag", be.Expected)
+		case "Mixed":
+			assert.NotEmpty(t, be.Field)
+			assert.NotEmpty(t, be.Expected)
+		}
+	}
+}
+
+func TestBuildErrorUnmappedResolverResult(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+
+	query := builder.Query()
+	query.FieldResolver("tags", func(ctx context.Context) ([]*test_uttils.Tag, error) {
+		return nil, nil
+	})
+	query.FieldResolver("labels", func(ctx context.Context) (chan string, error) {
+		return nil, nil
+	})
+
+	_, err := builder.Build()
+	assert.EqualError(t, err, "Resolver labels of object Query returns chan string which has no GraphQL mapping")
+
+	be := err.(gqbuilder.BuildErrors)[0].(*gqbuilder.BuildError)
+	assert.Equal(t, "Query", be.Object)
+	assert.Equal(t, "labels", be.Field)
+	assert.Equal(t, "chan string", be.Signature)
+}
+
+func TestBuildErrorUnmappedResolverSliceResult(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+
+	query := builder.Query()
+	query.FieldResolver("counters", func(ctx context.Context) ([]chan int, error) {
+		return nil, nil
+	})
+
+	_, err := builder.Build()
+	assert.EqualError(t, err, "Resolver counters of object Query returns []chan int which has no GraphQL mapping")
+
+	be := err.(gqbuilder.BuildErrors)[0].(*gqbuilder.BuildError)
+	assert.Equal(t, "Query", be.Object)
+	assert.Equal(t, "counters", be.Field)
+	assert.Equal(t, "[]chan int", be.Signature)
+	assert.NotEmpty(t, be.Expected)
+}
+
+func TestBuildErrorsFromBothPhases(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+
+	query := builder.Query()
+	query.FieldResolver("tags", func(ctx context.Context) ([]*test_uttils.Tag, error) {
+		return nil, nil
+	})
+	query.FieldResolver("tags", func(ctx context.Context) ([]*test_uttils.Tag, error) {
+		return nil, nil
+	})
+	query.FieldResolver("labels", func(ctx context.Context) (chan string, error) {
+		return nil, nil
+	})
+
+	_, err := builder.Build()
+	assert.EqualError(t, err, "Func with name tags aready exists\n"+
+		"Resolver labels of object Query returns chan string which has no GraphQL mapping")
+}
@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"context"
+	"github.com/graphql-go/graphql"
+	"github.com/mirogindev/gomer/gqbuilder"
+	"github.com/mirogindev/gomer/test_uttils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func buildFieldConfigSchema() *gqbuilder.SchemaBuilder {
+	builder := gqbuilder.GetBuilder()
+
+	tag := builder.Object("Tag", test_uttils.Tag{})
+	tag.Description = "Ticket tag"
+	tag.FieldResolver("short_title", func(ctx context.Context, o *test_uttils.Tag) (string, error) {
+		return o.Title, nil
+	}).Deprecated("Use title")
+
+	query := builder.Query()
+	query.Description = "Root query"
+	query.FieldResolver("tags", func(ctx context.Context, args struct {
+		Limit  int
+		Offset *int
+	}) ([]*test_uttils.Tag, error) {
+		var tags []*test_uttils.Tag
+		for i := 0; i < args.Limit; i++ {
+			tags = append(tags, &test_uttils.Tag{Title: "Tag"})
+		}
+		return tags, nil
+	}).Description("List of tags").
+		ArgDescription("limit", "Max number of tags").
+		ArgDefault("limit", 2).
+		ArgDescription("offset", "Number of tags to skip")
+
+	return builder
+}
+
+func TestFieldConfig(t *testing.T) {
+	schema, err := buildFieldConfigSchema().Build()
+	assert.Nil(t, err)
+
+	assert.Equal(t, "Root query", schema.QueryType().PrivateDescription)
+
+	field := schema.QueryType().Fields()["tags"]
+	assert.Equal(t, "List of tags", field.Description)
+	for _, a := range field.Args {
+		switch a.Name() {
+		case "limit":
+			assert.Equal(t, "Max number of tags", a.Description())
+			assert.Equal(t, 2, a.DefaultValue)
+			assert.Equal(t, "Int", a.Type.String())
+		case "offset":
+			assert.Equal(t, "Number of tags to skip", a.Description())
+			assert.Nil(t, a.DefaultValue)
+		}
+	}
+
+	tag := schema.Type("Tag").(*graphql.Object)
+	assert.Equal(t, "Ticket tag", tag.PrivateDescription)
+	assert.Equal(t, "Use title", tag.Fields()["short_title"].DeprecationReason)
+}
+
+func TestFieldConfigArgDefault(t *testing.T) {
+	schema, err := buildFieldConfigSchema().Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ tags { title } }`})
+	assert.Empty(t, r.Errors)
+	assert.Len(t, r.Data.(map[string]interface{})["tags"], 2)
+}
+
+func TestFieldConfigUnknownArg(t *testing.T) {
+	builder := buildFieldConfigSchema()
+	builder.Mutation().FieldResolver("tag_delete", func(ctx context.Context, args struct {
+		ID string
+	}) (bool, error) {
+		return true, nil
+	}).ArgDefault("title", "")
+
+	_, err := builder.Build()
+	assert.EqualError(t, err, "Field tag_delete of object Mutation has no arg title")
+}
+
+func TestFieldConfigIntrospection(t *testing.T) {
+	schema, err := buildFieldConfigSchema().Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `
+		{
+			__type(name: "Tag") { description, fields(includeDeprecated: true) { name, isDeprecated } }
+		}
+	`})
+	assert.Empty(t, r.Errors)
+
+	tag := r.Data.(map[string]interface{})["__type"].(map[string]interface{})
+	assert.Equal(t, "Ticket tag", tag["description"])
+	assert.Contains(t, tag["fields"], map[string]interface{}{"name": "short_title", "isDeprecated": true})
+}
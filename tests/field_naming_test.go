@@ -0,0 +1,146 @@
+package tests
+
+import (
+	"context"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/mirogindev/gomer/gqbuilder"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type namedPost struct {
+	PostID    int64  `json:"id"`
+	PostTitle string `json:"title"`
+	CreatedBy string
+}
+
+type namedPostInput struct {
+	PostTitle string `json:"title"`
+	CreatedBy string
+}
+
+func buildFieldNamingSchema(naming gqbuilder.FieldNamingFn) *gqbuilder.SchemaBuilder {
+	builder := gqbuilder.GetBuilder()
+	builder.SetFieldNaming(naming)
+
+	query := builder.Query()
+	query.FieldResolver("posts", func(ctx context.Context, args struct {
+		MaxCount int `json:"limit"`
+	}) ([]*namedPost, error) {
+		posts := make([]*namedPost, 0)
+		for i := 0; i < args.MaxCount; i++ {
+			posts = append(posts, &namedPost{PostID: int64(i), PostTitle: "Post", CreatedBy: "Author"})
+		}
+		return posts, nil
+	})
+
+	mutation := builder.Mutation()
+	mutation.FieldResolver("post_insert", func(ctx context.Context, args struct {
+		Input *namedPostInput
+	}) (*namedPost, error) {
+		return &namedPost{PostTitle: args.Input.PostTitle, CreatedBy: args.Input.CreatedBy}, nil
+	})
+	return builder
+}
+
+func TestCamelFieldNaming(t *testing.T) {
+	schema, err := buildFieldNamingSchema(gqbuilder.CamelFieldNaming).Build()
+	assert.Nil(t, err)
+
+	fields := schema.Type("namedPost").(*graphql.Object).Fields()
+	assert.Contains(t, fields, "postID")
+	assert.Contains(t, fields, "createdBy")
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ posts(maxCount: 2) { postTitle createdBy } }`})
+	assert.Empty(t, r.Errors)
+	posts := r.Data.(map[string]interface{})["posts"].([]interface{})
+	assert.Len(t, posts, 2)
+	assert.Equal(t, "Author", posts[0].(map[string]interface{})["createdBy"])
+
+	r = graphql.Do(graphql.Params{Schema: schema, RequestString: `mutation {
+		post_insert(input: {postTitle: "Post", createdBy: "Author"}) { postTitle createdBy }
+	}`})
+	assert.Empty(t, r.Errors)
+	post := r.Data.(map[string]interface{})["post_insert"].(map[string]interface{})
+	assert.Equal(t, "Post", post["postTitle"])
+	assert.Equal(t, "Author", post["createdBy"])
+}
+
+func TestJSONFieldNaming(t *testing.T) {
+	schema, err := buildFieldNamingSchema(gqbuilder.JSONFieldNaming).Build()
+	assert.Nil(t, err)
+
+	fields := schema.Type("namedPost").(*graphql.Object).Fields()
+	assert.Contains(t, fields, "id")
+	assert.Contains(t, fields, "title")
+	assert.Contains(t, fields, "created_by")
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ posts(limit: 1) { id title created_by } }`})
+	assert.Empty(t, r.Errors)
+	post := r.Data.(map[string]interface{})["posts"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "Post", post["title"])
+	assert.Equal(t, "Author", post["created_by"])
+
+	r = graphql.Do(graphql.Params{Schema: schema, RequestString: `mutation {
+		post_insert(input: {title: "Post", created_by: "Author"}) { title created_by }
+	}`})
+	assert.Empty(t, r.Errors)
+	post = r.Data.(map[string]interface{})["post_insert"].(map[string]interface{})
+	assert.Equal(t, "Post", post["title"])
+	assert.Equal(t, "Author", post["created_by"])
+}
+
+func TestCustomFieldNaming(t *testing.T) {
+	schema, err := buildFieldNamingSchema(func(f reflect.StructField) string {
+		return "f" + f.Name
+	}).Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ posts(fMaxCount: 1) { fPostTitle } }`})
+	assert.Empty(t, r.Errors)
+	post := r.Data.(map[string]interface{})["posts"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "Post", post["fPostTitle"])
+}
+
+func TestDecodeArgsWithFieldNaming(t *testing.T) {
+	camel := buildFieldNamingSchema(gqbuilder.CamelFieldNaming)
+	v, err := camel.DecodeArgs(reflect.TypeOf(namedPostInput{}), map[string]interface{}{
+		"postTitle": "Post",
+		"createdBy": "Author",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, namedPostInput{PostTitle: "Post", CreatedBy: "Author"}, v.Interface())
+
+	js := buildFieldNamingSchema(gqbuilder.JSONFieldNaming)
+	input := js.ReflectStructRecursive(reflect.TypeOf(namedPostInput{}), map[string]interface{}{
+		"title":      "Post",
+		"created_by": "Author",
+	})
+	assert.Equal(t, namedPostInput{PostTitle: "Post", CreatedBy: "Author"}, input.Interface())
+}
+
+func TestParseSelectionsWithFieldNaming(t *testing.T) {
+	builder := buildFieldNamingSchema(gqbuilder.CamelFieldNaming)
+	schema, err := builder.Build()
+	assert.Nil(t, err)
+
+	doc, err := parser.Parse(parser.ParseParams{Source: `{ posts(maxCount: 2) { postTitle } }`})
+	assert.Nil(t, err)
+	p := graphql.ResolveParams{
+		Info: graphql.ResolveInfo{
+			Schema:     schema,
+			Operation:  doc.Definitions[0].(ast.Definition),
+			ParentType: schema.QueryType(),
+		},
+	}
+
+	selections, err := builder.ParseSelections(p)
+	assert.Nil(t, err)
+	assert.Len(t, selections, 1)
+	assert.Equal(t, "posts", selections[0].Name)
+	assert.Equal(t, int64(2), reflect.ValueOf(selections[0].Args).FieldByName("MaxCount").Int())
+	assert.Equal(t, "postTitle", selections[0].SelectionSet.Selections[0].Name)
+}
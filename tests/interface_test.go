@@ -0,0 +1,178 @@
+package tests
+
+import (
+	"context"
+	"github.com/graphql-go/graphql"
+	"github.com/mirogindev/gomer/gqbuilder"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type FeedItem interface {
+	IsFeedItem()
+}
+
+type FeedItemFields struct {
+	ID    string
+	Title string
+}
+
+type Article struct {
+	ID    string
+	Title string
+	Body  string
+}
+
+type Video struct {
+	ID       string
+	Title    string
+	Duration int
+}
+
+func (a *Article) IsFeedItem() {}
+
+func (v *Video) IsFeedItem() {}
+
+func buildFeedSchema() *gqbuilder.SchemaBuilder {
+	builder := gqbuilder.GetBuilder()
+	feedItem := builder.Interface("FeedItem", (*FeedItem)(nil), FeedItemFields{})
+	feedItem.Implementation(Article{})
+	feedItem.Implementation(Video{})
+
+	query := builder.Query()
+	query.FieldResolver("feed", func(ctx context.Context) ([]FeedItem, error) {
+		return []FeedItem{&Article{ID: "1", Title: "Article1"}, &Video{ID: "2", Title: "Video1"}}, nil
+	})
+	return builder
+}
+
+func TestInterfaceQuery(t *testing.T) {
+	schema, err := buildFeedSchema().Build()
+	assert.Nil(t, err)
+
+	query := `
+		{
+			feed { id, title, __typename }
+		}
+	`
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+	assert.Empty(t, r.Errors)
+
+	data := r.Data.(map[string]interface{})
+	items := data["feed"].([]interface{})
+	assert.Len(t, items, 2)
+	assert.Equal(t, "Article", items[0].(map[string]interface{})["__typename"])
+	assert.Equal(t, "Video", items[1].(map[string]interface{})["__typename"])
+	assert.Equal(t, "Video1", items[1].(map[string]interface{})["title"])
+}
+
+type invalidFeedFields struct {
+	Title string
+	Color string
+}
+
+func TestInterfaceMissingField(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+	feedItem := builder.Interface("FeedItem", (*FeedItem)(nil), invalidFeedFields{})
+	feedItem.Implementation(Article{})
+
+	query := builder.Query()
+	query.FieldResolver("feed", func(ctx context.Context) ([]FeedItem, error) {
+		return nil, nil
+	})
+
+	_, err := builder.Build()
+	assert.EqualError(t, err, "Interface FeedItem field color is not declared by tests.Article")
+}
+
+type invalidFeedNamedFields struct {
+	Title           string
+	BackgroundColor string
+}
+
+func TestInterfaceMissingFieldWithFieldNaming(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+	builder.SetFieldNaming(gqbuilder.CamelFieldNaming)
+	feedItem := builder.Interface("FeedItem", (*FeedItem)(nil), invalidFeedNamedFields{})
+	feedItem.Implementation(Article{})
+
+	query := builder.Query()
+	query.FieldResolver("feed", func(ctx context.Context) ([]FeedItem, error) {
+		return nil, nil
+	})
+
+	_, err := builder.Build()
+	assert.EqualError(t, err, "Interface FeedItem field backgroundColor is not declared by tests.Article")
+}
+
+type podcastFeedFields struct {
+	ID       string
+	Title    string
+	Subtitle *string
+	Rating   *int
+}
+
+type Podcast struct {
+	ID       string
+	Heading  string `gomer:"name:title"`
+	Subtitle string
+	Episodes int
+}
+
+func (p *Podcast) IsFeedItem() {}
+
+type ratedPodcast struct {
+	ID       string
+	Title    string
+	Subtitle string
+	Rating   string
+}
+
+func (p *ratedPodcast) IsFeedItem() {}
+
+func TestInterfaceImplementationByGraphQLFields(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+	feedItem := builder.Interface("FeedItem", (*FeedItem)(nil), podcastFeedFields{})
+	feedItem.Implementation(Podcast{})
+
+	podcast := builder.Object("Podcast", Podcast{})
+	podcast.FieldResolver("rating", func(ctx context.Context, o *Podcast) (int, error) {
+		return o.Episodes * 2, nil
+	})
+
+	query := builder.Query()
+	query.FieldResolver("feed", func(ctx context.Context) ([]FeedItem, error) {
+		return []FeedItem{&Podcast{ID: "1", Heading: "Podcast1", Subtitle: "Weekly", Episodes: 3}}, nil
+	})
+
+	schema, err := builder.Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ feed { id title subtitle rating } }`})
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, map[string]interface{}{
+		"id":       "1",
+		"title":    "Podcast1",
+		"subtitle": "Weekly",
+		"rating":   6,
+	}, r.Data.(map[string]interface{})["feed"].([]interface{})[0])
+}
+
+func TestInterfaceFieldTypeMismatch(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+	feedItem := builder.Interface("FeedItem", (*FeedItem)(nil), podcastFeedFields{})
+	feedItem.Implementation(ratedPodcast{})
+
+	query := builder.Query()
+	query.FieldResolver("feed", func(ctx context.Context) ([]FeedItem, error) {
+		return nil, nil
+	})
+
+	_, err := builder.Build()
+	assert.EqualError(t, err, "Interface FeedItem field rating has type Int, but tests.ratedPodcast declares String!")
+
+	be := err.(gqbuilder.BuildErrors)[0].(*gqbuilder.BuildError)
+	assert.Equal(t, "rating", be.Field)
+	assert.Equal(t, "String!", be.Signature)
+	assert.Equal(t, "Int", be.Expected)
+}
@@ -0,0 +1,38 @@
+package tests
+
+import (
+	"context"
+	"github.com/graphql-go/graphql"
+	"github.com/mirogindev/gomer/gqbuilder"
+	"github.com/mirogindev/gomer/test_uttils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestResolverNilResults(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+
+	query := builder.Query()
+	query.FieldResolver("tag", func(ctx context.Context) (*test_uttils.Tag, error) {
+		return nil, nil
+	})
+	query.FieldResolver("tags", func(ctx context.Context, args struct {
+		Limit *int
+	}) ([]*test_uttils.Tag, error) {
+		if args.Limit != nil {
+			return []*test_uttils.Tag{{Title: "Tag"}}, nil
+		}
+		return nil, nil
+	})
+
+	schema, err := builder.Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ tag { title } tags { title } }`})
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, map[string]interface{}{"tag": nil, "tags": []interface{}{}}, r.Data)
+
+	r = graphql.Do(graphql.Params{Schema: schema, RequestString: `{ tags(limit: 1) { title } }`})
+	assert.Empty(t, r.Errors)
+	assert.Len(t, r.Data.(map[string]interface{})["tags"], 1)
+}
@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/graphql-go/graphql"
+	"github.com/mirogindev/gomer/gqbuilder"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+type jsonPayload struct {
+	Kind string `json:"kind"`
+}
+
+type Document struct {
+	Attributes map[string]interface{}
+	Labels     map[string]string
+	Payload    interface{}
+	Raw        json.RawMessage
+}
+
+type DocumentInput struct {
+	Attributes map[string]interface{}
+	Labels     map[string]string
+	Payload    interface{}
+	Raw        json.RawMessage
+}
+
+func buildDocumentSchema(got *DocumentInput) *gqbuilder.SchemaBuilder {
+	builder := gqbuilder.GetBuilder()
+	query := builder.Query()
+	query.FieldResolver("document", func(ctx context.Context) (*Document, error) {
+		return &Document{
+			Attributes: map[string]interface{}{"pages": 3, "tags": []interface{}{"a", "b"}},
+			Payload:    jsonPayload{Kind: "invoice"},
+			Raw:        json.RawMessage(`{"total": 1.5}`),
+		}, nil
+	})
+	query.FieldResolver("labels", func(ctx context.Context) (map[string]string, error) {
+		return map[string]string{"env": "prod"}, nil
+	})
+	mutation := builder.Mutation()
+	mutation.FieldResolver("document_save", func(ctx context.Context, args struct {
+		Input *DocumentInput
+	}) (bool, error) {
+		*got = *args.Input
+		return true, nil
+	})
+	return builder
+}
+
+func TestJSONScalarOutput(t *testing.T) {
+	var got DocumentInput
+	schema, err := buildDocumentSchema(&got).Build()
+	assert.Nil(t, err)
+	assert.Equal(t, "JSON", schema.Type("Document").(*graphql.Object).Fields()["attributes"].Type.Name())
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ document { attributes labels payload raw } labels }`})
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, map[string]interface{}{
+		"document": map[string]interface{}{
+			"attributes": map[string]interface{}{"pages": 3, "tags": []interface{}{"a", "b"}},
+			"labels":     nil,
+			"payload":    map[string]interface{}{"kind": "invoice"},
+			"raw":        map[string]interface{}{"total": 1.5},
+		},
+		"labels": map[string]interface{}{"env": "prod"},
+	}, r.Data)
+}
+
+func TestJSONScalarLiteralInput(t *testing.T) {
+	var got DocumentInput
+	schema, err := buildDocumentSchema(&got).Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `mutation {
+		document_save(input: {
+			attributes: {pages: 3, nested: {list: [1, "x", true, 2.5]}},
+			labels: {env: "prod"},
+			payload: "text",
+			raw: [1, 2]
+		})
+	}`})
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, map[string]interface{}{
+		"pages":  int64(3),
+		"nested": map[string]interface{}{"list": []interface{}{int64(1), "x", true, 2.5}},
+	}, got.Attributes)
+	assert.Equal(t, map[string]string{"env": "prod"}, got.Labels)
+	assert.Equal(t, "text", got.Payload)
+	assert.JSONEq(t, `[1, 2]`, string(got.Raw))
+}
+
+func TestJSONScalarVariableInput(t *testing.T) {
+	var got DocumentInput
+	schema, err := buildDocumentSchema(&got).Build()
+	assert.Nil(t, err)
+
+	var input map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"attributes": {"pages": 3},
+		"labels": {"env": "prod"},
+		"payload": [1, {"a": null}],
+		"raw": {"total": 1.5}
+	}`), &input))
+
+	r := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  `mutation($input: DocumentInput) { document_save(input: $input) }`,
+		VariableValues: map[string]interface{}{"input": input},
+	})
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, map[string]interface{}{"pages": float64(3)}, got.Attributes)
+	assert.Equal(t, map[string]string{"env": "prod"}, got.Labels)
+	assert.Equal(t, []interface{}{float64(1), map[string]interface{}{"a": nil}}, got.Payload)
+	assert.JSONEq(t, `{"total": 1.5}`, string(got.Raw))
+
+	r = graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  `mutation($input: DocumentInput) { document_save(input: $input) }`,
+		VariableValues: map[string]interface{}{"input": map[string]interface{}{"labels": map[string]interface{}{"env": 1}}},
+	})
+	assert.Len(t, r.Errors, 1)
+}
+
+func TestJSONScalarVariableInsideLiteral(t *testing.T) {
+	var got DocumentInput
+	schema, err := buildDocumentSchema(&got).Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  `mutation($pages: JSON) { document_save(input: {attributes: {pages: $pages}}) }`,
+		VariableValues: map[string]interface{}{"pages": 3},
+	})
+	assert.Len(t, r.Errors, 1)
+	assert.True(t, strings.HasPrefix(r.Errors[0].Message, `Argument "input" has invalid value`), r.Errors[0].Message)
+	assert.Nil(t, got.Attributes)
+}
@@ -0,0 +1,151 @@
+package tests
+
+import (
+	"context"
+	"github.com/graphql-go/graphql"
+	"github.com/mirogindev/gomer/gqbuilder"
+	"github.com/mirogindev/gomer/test_uttils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type Tag struct {
+	ID    string
+	Color string
+}
+
+type Label struct {
+	_     struct{} `gomer:"typeName:TicketLabel"`
+	Title string
+}
+
+type namingTicket struct {
+	Title  string
+	Labels []*Label
+}
+
+func TestObjectTypeName(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+
+	ticket := builder.Object("TicketType", namingTicket{})
+	ticket.FieldResolver("label_count", func(ctx context.Context, o *namingTicket) (int, error) {
+		return len(o.Labels), nil
+	})
+
+	query := builder.Query()
+	query.FieldResolver("tickets", func(ctx context.Context) ([]*namingTicket, error) {
+		return []*namingTicket{{Title: "Ticket1", Labels: []*Label{{Title: "Label1"}}}}, nil
+	})
+
+	schema, err := builder.Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `
+		{
+			tickets { __typename, label_count, labels { __typename, title } }
+		}
+	`})
+	assert.Empty(t, r.Errors)
+
+	ticketData := r.Data.(map[string]interface{})["tickets"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "TicketType", ticketData["__typename"])
+	assert.Equal(t, 1, ticketData["label_count"])
+	labelData := ticketData["labels"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "TicketLabel", labelData["__typename"])
+	assert.Equal(t, "Label1", labelData["title"])
+}
+
+func buildCollidingSchema() *gqbuilder.SchemaBuilder {
+	builder := gqbuilder.GetBuilder()
+
+	query := builder.Query()
+	query.FieldResolver("tags", func(ctx context.Context) ([]*test_uttils.Tag, error) {
+		return nil, nil
+	})
+	query.FieldResolver("colors", func(ctx context.Context) ([]*Tag, error) {
+		return nil, nil
+	})
+	return builder
+}
+
+func TestTypeNameCollision(t *testing.T) {
+	_, err := buildCollidingSchema().Build()
+	assert.EqualError(t, err, "GraphQL type name Tag is used by several Go types: "+
+		"github.com/mirogindev/gomer/test_uttils.Tag, github.com/mirogindev/gomer/tests.Tag")
+
+	errs, ok := err.(gqbuilder.BuildErrors)
+	assert.True(t, ok)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "Tag", errs[0].(*gqbuilder.BuildError).Object)
+}
+
+func TestPackageTypeNaming(t *testing.T) {
+	builder := buildCollidingSchema()
+	builder.SetTypeNaming(gqbuilder.PackageTypeNaming)
+
+	schema, err := builder.Build()
+	assert.Nil(t, err)
+	assert.NotNil(t, schema.Type("TestUttilsTag"))
+	assert.NotNil(t, schema.Type("TestsTag"))
+}
+
+func TestInputOutputNameDisambiguation(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+
+	query := builder.Query()
+	query.FieldResolver("tags", func(ctx context.Context) ([]*test_uttils.Tag, error) {
+		return nil, nil
+	})
+
+	mutation := builder.Mutation()
+	mutation.FieldResolver("tag_update", func(ctx context.Context, args struct {
+		Input *test_uttils.Tag
+	}) (*test_uttils.Tag, error) {
+		return args.Input, nil
+	})
+
+	schema, err := builder.Build()
+	assert.Nil(t, err)
+	assert.IsType(t, &graphql.Object{}, schema.Type("Tag"))
+	assert.IsType(t, &graphql.InputObject{}, schema.Type("TagInput"))
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `
+		mutation {
+			tag_update(input: { id: "1", title: "Tag1" }) { id, title }
+		}
+	`})
+	assert.Empty(t, r.Errors)
+	tag := r.Data.(map[string]interface{})["tag_update"].(map[string]interface{})
+	assert.Equal(t, "Tag1", tag["title"])
+}
+
+func TestInputNamingPrefixAndSuffix(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+	builder.SetInputNaming("New", "Data")
+
+	query := builder.Query()
+	query.FieldResolver("tags", func(ctx context.Context) ([]*test_uttils.Tag, error) {
+		return nil, nil
+	})
+
+	mutation := builder.Mutation()
+	mutation.FieldResolver("tag_update", func(ctx context.Context, args struct {
+		Input *test_uttils.Tag
+	}) (*test_uttils.Tag, error) {
+		return args.Input, nil
+	})
+
+	schema, err := builder.Build()
+	assert.Nil(t, err)
+	assert.IsType(t, &graphql.InputObject{}, schema.Type("NewTagData"))
+}
+
+func TestPrefixTag(t *testing.T) {
+	schema, err := BuildTestSchema()
+	assert.Nil(t, err)
+
+	inner, ok := schema.Type("InnerTicketFilterInput").(*graphql.InputObject)
+	assert.True(t, ok)
+	assert.Contains(t, inner.Fields(), "title")
+	assert.NotContains(t, inner.Fields(), "and")
+}
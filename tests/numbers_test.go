@@ -0,0 +1,232 @@
+package tests
+
+import (
+	"context"
+	"github.com/graphql-go/graphql"
+	"github.com/mirogindev/gomer/gqbuilder"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type Counters struct {
+	Tiny   int8
+	Small  int16
+	Medium int32
+	Big    int64
+	Count  uint
+	Byte   uint8
+	Port   *uint16
+	Wide   uint32
+	Huge   uint64
+	Ratio  float32
+}
+
+type countersArgs struct {
+	Tiny *int8
+	Byte *uint8
+	Port *uint16
+	Big  *int64
+	Huge *uint64
+}
+
+func buildCountersSchema(asString bool, got *countersArgs) *gqbuilder.SchemaBuilder {
+	builder := gqbuilder.GetBuilder()
+	builder.SetInt64AsString(asString)
+	query := builder.Query()
+	query.FieldResolver("counters", func(ctx context.Context, args countersArgs) (*Counters, error) {
+		*got = args
+		port := uint16(math.MaxUint16)
+		return &Counters{
+			Tiny:   math.MinInt8,
+			Small:  math.MaxInt16,
+			Medium: math.MinInt32,
+			Big:    math.MaxInt64,
+			Count:  42,
+			Byte:   math.MaxUint8,
+			Port:   &port,
+			Wide:   math.MaxUint32,
+			Huge:   math.MaxUint64,
+			Ratio:  1.5,
+		}, nil
+	})
+	return builder
+}
+
+func TestNumericScalars(t *testing.T) {
+	var got countersArgs
+	schema, err := buildCountersSchema(false, &got).Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `{
+		counters(tiny: -5, byte: 200, port: "65535", big: 9007199254740993, huge: "18446744073709551615") {
+			tiny small medium big count byte port wide huge ratio
+		}
+	}`})
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, map[string]interface{}{
+		"tiny":   -128,
+		"small":  32767,
+		"medium": -2147483648,
+		"big":    int64(math.MaxInt64),
+		"count":  uint64(42),
+		"byte":   255,
+		"port":   65535,
+		"wide":   4294967295,
+		"huge":   uint64(math.MaxUint64),
+		"ratio":  float32(1.5),
+	}, r.Data.(map[string]interface{})["counters"])
+
+	assert.Equal(t, int8(-5), *got.Tiny)
+	assert.Equal(t, uint8(200), *got.Byte)
+	assert.Equal(t, uint16(65535), *got.Port)
+	assert.Equal(t, int64(9007199254740993), *got.Big)
+	assert.Equal(t, uint64(math.MaxUint64), *got.Huge)
+}
+
+func TestNumericScalarsRange(t *testing.T) {
+	var got countersArgs
+	schema, err := buildCountersSchema(false, &got).Build()
+	assert.Nil(t, err)
+
+	cases := map[string]string{
+		`{ counters(tiny: 128) { tiny } }`: `Argument "tiny" has invalid value 128.`,
+		`{ counters(byte: -1) { byte } }`:  `Argument "byte" has invalid value -1.`,
+		`{ counters(port: 1.5) { byte } }`: `Argument "port" has invalid value 1.5.`,
+	}
+	for query, msg := range cases {
+		r := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+		assert.Len(t, r.Errors, 1)
+		assert.True(t, strings.HasPrefix(r.Errors[0].Message, msg), r.Errors[0].Message)
+	}
+
+	r := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  `query($byte: uint8) { counters(byte: $byte) { byte } }`,
+		VariableValues: map[string]interface{}{"byte": float64(256)},
+	})
+	assert.Len(t, r.Errors, 1)
+	assert.True(t, strings.HasPrefix(r.Errors[0].Message, `Variable "$byte" got invalid value 256.`), r.Errors[0].Message)
+}
+
+func TestInt64AsString(t *testing.T) {
+	var got countersArgs
+	schema, err := buildCountersSchema(true, &got).Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  `query($big: int64_string) { counters(big: $big, huge: 7) { big huge wide } }`,
+		VariableValues: map[string]interface{}{"big": "-9007199254740993"},
+	})
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, map[string]interface{}{
+		"big":  "9223372036854775807",
+		"huge": "18446744073709551615",
+		"wide": 4294967295,
+	}, r.Data.(map[string]interface{})["counters"])
+	assert.Equal(t, int64(-9007199254740993), *got.Big)
+	assert.Equal(t, uint64(7), *got.Huge)
+}
+
+type legacyID int64
+
+type Account struct {
+	ID      int64
+	Balance uint64
+	Legacy  legacyID
+}
+
+func TestInt64AsStringOverridesNamedScalar(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+	builder.RegisterScalar("int64", gqbuilder.Int64Scalar)
+	builder.RegisterScalarFor(reflect.TypeOf(legacyID(0)), gqbuilder.Int64Scalar)
+	builder.SetInt64AsString(true)
+
+	query := builder.Query()
+	query.FieldResolver("account", func(ctx context.Context, args struct {
+		ID int64
+	}) (*Account, error) {
+		return &Account{ID: args.ID, Balance: math.MaxUint64, Legacy: 5}, nil
+	})
+	schema, err := builder.Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `{
+		account(id: "9007199254740993") { id balance legacy }
+	}`})
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, map[string]interface{}{
+		"id":      "9007199254740993",
+		"balance": "18446744073709551615",
+		"legacy":  int64(5),
+	}, r.Data.(map[string]interface{})["account"])
+}
+
+type Cents int64
+
+type Port uint16
+
+type Quantity int
+
+type Invoice struct {
+	Total    Cents
+	Port     *Port
+	Quantity Quantity
+	Lines    []Cents
+}
+
+func TestNamedIntegerTypes(t *testing.T) {
+	var got struct {
+		Total    Cents
+		Port     *Port
+		Quantity Quantity
+		Lines    []Cents
+	}
+	builder := gqbuilder.GetBuilder()
+	query := builder.Query()
+	query.FieldResolver("invoice", func(ctx context.Context, args struct {
+		Total    Cents
+		Port     *Port
+		Quantity Quantity
+		Lines    []Cents
+	}) (*Invoice, error) {
+		got = args
+		return &Invoice{Total: args.Total, Port: args.Port, Quantity: args.Quantity, Lines: args.Lines}, nil
+	})
+	query.FieldResolver("ports", func(ctx context.Context) ([]Port, error) {
+		return []Port{80, 443}, nil
+	})
+	schema, err := builder.Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `query($port: uint16) {
+			invoice(total: 9007199254740993, port: $port, quantity: 3, lines: [1, 2]) { total port quantity lines }
+			ports
+		}`,
+		VariableValues: map[string]interface{}{"port": float64(8080)},
+	})
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, map[string]interface{}{
+		"invoice": map[string]interface{}{
+			"total":    int64(9007199254740993),
+			"port":     8080,
+			"quantity": 3,
+			"lines":    []interface{}{int64(1), int64(2)},
+		},
+		"ports": []interface{}{80, 443},
+	}, r.Data)
+
+	assert.Equal(t, Cents(9007199254740993), got.Total)
+	assert.Equal(t, Port(8080), *got.Port)
+	assert.Equal(t, Quantity(3), got.Quantity)
+	assert.Equal(t, []Cents{1, 2}, got.Lines)
+
+	r = graphql.Do(graphql.Params{Schema: schema, RequestString: `{ invoice(total: 1, port: 65536, quantity: 1, lines: []) { port } }`})
+	assert.Len(t, r.Errors, 1)
+	assert.True(t, strings.HasPrefix(r.Errors[0].Message, `Argument "port" has invalid value 65536.`), r.Errors[0].Message)
+}
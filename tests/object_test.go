@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"context"
+	"github.com/graphql-go/graphql"
+	"github.com/mirogindev/gomer/gqbuilder"
+	"github.com/mirogindev/gomer/test_uttils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func buildComputedSchema() *gqbuilder.SchemaBuilder {
+	builder := gqbuilder.GetBuilder()
+
+	ticket := builder.Object("Ticket", test_uttils.Ticket{})
+	ticket.FieldResolver("tag_count", func(ctx context.Context, o *test_uttils.Ticket) (int, error) {
+		return len(o.Tags), nil
+	})
+	ticket.FieldResolver("short_title", func(ctx context.Context, o test_uttils.Ticket, args struct {
+		Length int
+	}) (string, error) {
+		if len(o.Title) > args.Length {
+			return o.Title[:args.Length], nil
+		}
+		return o.Title, nil
+	})
+
+	query := builder.Query()
+	query.FieldResolver("tickets", func(ctx context.Context) ([]*test_uttils.Ticket, error) {
+		tags := []*test_uttils.Tag{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+		return []*test_uttils.Ticket{{ID: "1", Title: "Ticket1", Tags: tags}}, nil
+	})
+	return builder
+}
+
+func TestComputedFields(t *testing.T) {
+	schema, err := buildComputedSchema().Build()
+	assert.Nil(t, err)
+
+	query := `
+		{
+			tickets { title, tag_count, short_title(length: 4) }
+		}
+	`
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+	assert.Empty(t, r.Errors)
+
+	data := r.Data.(map[string]interface{})
+	ticket := data["tickets"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, 3, ticket["tag_count"])
+	assert.Equal(t, "Tick", ticket["short_title"])
+}
+
+func TestComputedFieldInvalidParent(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+
+	ticket := builder.Object("Ticket", test_uttils.Ticket{})
+	ticket.FieldResolver("tag_count", func(ctx context.Context, o *test_uttils.Tag) (int, error) {
+		return 0, nil
+	})
+
+	query := builder.Query()
+	query.FieldResolver("tickets", func(ctx context.Context) ([]*test_uttils.Ticket, error) {
+		return nil, nil
+	})
+
+	_, err := builder.Build()
+	assert.EqualError(t, err, "Resolver tag_count of object Ticket cannot be attached, parent param is *test_uttils.Tag, expected test_uttils.Ticket or *test_uttils.Ticket")
+}
+
+type untaggedReport struct {
+	ReportTitle     string
+	NumbersRequired []int64
+	Author          *untaggedAuthor
+}
+
+type untaggedAuthor struct {
+	FullName string
+}
+
+func TestFieldsResolvedByIndex(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+	query := builder.Query()
+	query.FieldResolver("reports", func(ctx context.Context) ([]*untaggedReport, error) {
+		return []*untaggedReport{
+			{ReportTitle: "Report", NumbersRequired: []int64{1, 2}, Author: &untaggedAuthor{FullName: "Author"}},
+			{ReportTitle: "Draft"},
+		}, nil
+	})
+	schema, err := builder.Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ reports { report_title numbers_required author { full_name } } }`})
+	assert.Empty(t, r.Errors)
+
+	reports := r.Data.(map[string]interface{})["reports"].([]interface{})
+	report := reports[0].(map[string]interface{})
+	assert.Equal(t, "Report", report["report_title"])
+	assert.Equal(t, []interface{}{int64(1), int64(2)}, report["numbers_required"])
+	assert.Equal(t, "Author", report["author"].(map[string]interface{})["full_name"])
+	assert.Nil(t, reports[1].(map[string]interface{})["author"])
+}
@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"context"
+	"github.com/graphql-go/graphql"
+	"github.com/mirogindev/gomer/gqbuilder"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type PatchTagInput struct {
+	Title *string
+}
+
+type PatchTicketInput struct {
+	ID     string
+	Title  *string
+	Number *int
+	Tags   []*PatchTagInput
+}
+
+func buildPatchSchema(fields **gqbuilder.InputFields) *gqbuilder.SchemaBuilder {
+	builder := gqbuilder.GetBuilder()
+	builder.Query().FieldResolver("ping", func(ctx context.Context) (string, error) {
+		return "pong", nil
+	})
+	builder.Mutation().FieldResolver("ticket_update", func(ctx context.Context, args struct {
+		Input *PatchTicketInput
+	}) (string, error) {
+		*fields = gqbuilder.InputFieldsFromContext(ctx)
+		return args.Input.ID, nil
+	})
+	return builder
+}
+
+func TestInputFieldsFromLiterals(t *testing.T) {
+	var fields *gqbuilder.InputFields
+	schema, err := buildPatchSchema(&fields).Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `mutation {
+		ticket_update(input: {id: "1", title: "New", tags: [{}, {title: "Tag"}]})
+	}`})
+	assert.Empty(t, r.Errors)
+
+	assert.Equal(t, gqbuilder.FieldSet, fields.State("input"))
+	assert.Equal(t, gqbuilder.FieldSet, fields.State("input.title"))
+	assert.Equal(t, gqbuilder.FieldAbsent, fields.State("input.number"))
+	assert.Equal(t, 2, fields.Get("input.tags").Len())
+	assert.Equal(t, gqbuilder.FieldAbsent, fields.State("input.tags[0].title"))
+	assert.Equal(t, gqbuilder.FieldSet, fields.State("input.tags[1].title"))
+	assert.Equal(t, gqbuilder.FieldAbsent, fields.State("input.tags[2].title"))
+	assert.ElementsMatch(t, []string{"id", "title", "tags"}, fields.Field("input").Names())
+}
+
+func TestInputFieldsFromVariables(t *testing.T) {
+	var fields *gqbuilder.InputFields
+	schema, err := buildPatchSchema(&fields).Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `mutation($input: PatchTicketInput!) { ticket_update(input: $input) }`,
+		VariableValues: map[string]interface{}{
+			"input": map[string]interface{}{
+				"id":    "1",
+				"title": nil,
+				"tags": []interface{}{
+					map[string]interface{}{"title": nil},
+					map[string]interface{}{"title": "Tag"},
+				},
+			},
+		},
+	})
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, map[string]interface{}{"ticket_update": "1"}, r.Data)
+
+	assert.True(t, fields.IsNull("input.title"))
+	assert.False(t, fields.Has("input.number"))
+	assert.True(t, fields.IsNull("input.tags[0].title"))
+	assert.Equal(t, gqbuilder.FieldSet, fields.State("input.tags[1].title"))
+}
+
+func TestInputFieldsFromNestedVariables(t *testing.T) {
+	var fields *gqbuilder.InputFields
+	schema, err := buildPatchSchema(&fields).Build()
+	assert.Nil(t, err)
+	query := `mutation($title: String, $number: Int) {
+		ticket_update(input: {id: "1", title: $title, number: $number})
+	}`
+
+	r := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  query,
+		VariableValues: map[string]interface{}{"title": nil},
+	})
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, gqbuilder.FieldNull, fields.State("input.title"))
+	assert.Equal(t, gqbuilder.FieldAbsent, fields.State("input.number"))
+}
+
+func TestInputFieldsOutsideResolver(t *testing.T) {
+	fields := gqbuilder.InputFieldsFromContext(context.Background())
+	assert.Nil(t, fields)
+	assert.Equal(t, gqbuilder.FieldAbsent, fields.State("input.title"))
+}
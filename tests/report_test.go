@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"context"
+	"github.com/graphql-go/graphql"
+	"github.com/mirogindev/gomer/gqbuilder"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type reportDevice struct {
+	Name     string
+	Events   chan string
+	Callback func()
+	Labels   map[string]string
+	Payload  interface{}
+	Level    complex64
+	serial   string
+}
+
+type reportDeviceInput struct {
+	Name   string
+	Labels map[string]string
+}
+
+func buildReportSchema() *gqbuilder.SchemaBuilder {
+	builder := gqbuilder.GetBuilder()
+
+	query := builder.Query()
+	query.FieldResolver("devices", func(ctx context.Context, args struct {
+		Filter *reportDeviceInput
+		Hook   func()
+	}) ([]*reportDevice, error) {
+		return []*reportDevice{{Name: "Device", serial: "1"}}, nil
+	})
+
+	return builder
+}
+
+func TestBuildReportSkipsUnsupportedFields(t *testing.T) {
+	schema, report, err := buildReportSchema().BuildWithReport()
+	assert.Nil(t, err)
+
+	fields := schema.Type("reportDevice").(*graphql.Object).Fields()
+	assert.Len(t, fields, 3)
+	assert.Contains(t, fields, "name")
+	assert.Contains(t, fields, "labels")
+	assert.Contains(t, fields, "payload")
+
+	skipped := make(map[string]string)
+	for _, f := range report.SkippedFields {
+		skipped[f.Path] = f.Reason
+	}
+	assert.Equal(t, map[string]string{
+		"Query.devices.Hook":          "func kind is not supported",
+		"tests.reportDevice.Callback": "func kind is not supported",
+		"tests.reportDevice.Events":   "chan kind is not supported",
+		"tests.reportDevice.Level":    "type complex64 has no GraphQL mapping",
+		"tests.reportDevice.serial":   "unexported field",
+	}, skipped)
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ devices(filter: {name: "Device"}) { name } }`})
+	assert.Empty(t, r.Errors)
+}
+
+func TestBuildReportStrict(t *testing.T) {
+	builder := buildReportSchema()
+	builder.SetStrict(true)
+
+	_, report, err := builder.BuildWithReport()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Field tests.reportDevice.Events of type chan string is skipped: chan kind is not supported")
+	assert.Len(t, report.SkippedFields, 5)
+
+	errs, ok := err.(gqbuilder.BuildErrors)
+	assert.True(t, ok)
+	assert.Len(t, errs, 5)
+}
+
+type reportFeedFilterInput struct {
+	Title string
+	Item  FeedItem
+}
+
+type reportLevelInput struct {
+	Level complex64
+}
+
+func buildReportFeedSchema() *gqbuilder.SchemaBuilder {
+	builder := gqbuilder.GetBuilder()
+	feedItem := builder.Interface("FeedItem", (*FeedItem)(nil), FeedItemFields{})
+	feedItem.Implementation(Article{})
+
+	query := builder.Query()
+	query.FieldResolver("feed", func(ctx context.Context, args struct {
+		Filter *reportFeedFilterInput
+	}) ([]FeedItem, error) {
+		return nil, nil
+	})
+	return builder
+}
+
+func TestBuildReportAbstractInputField(t *testing.T) {
+	schema, report, err := buildReportFeedSchema().BuildWithReport()
+	assert.Nil(t, err)
+
+	fields := schema.Type("reportFeedFilterInput").(*graphql.InputObject).Fields()
+	assert.Len(t, fields, 1)
+	assert.Contains(t, fields, "title")
+	assert.Len(t, report.SkippedFields, 1)
+	assert.Equal(t, "tests.reportFeedFilterInput.Item", report.SkippedFields[0].Path)
+	assert.Equal(t, "interface or union type tests.FeedItem cannot be used in inputs", report.SkippedFields[0].Reason)
+
+	builder := buildReportFeedSchema()
+	builder.SetStrict(true)
+	_, _, err = builder.BuildWithReport()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Field tests.reportFeedFilterInput.Item of type tests.FeedItem is skipped")
+}
+
+func TestBuildReportInputWithoutFields(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+	query := builder.Query()
+	query.FieldResolver("levels", func(ctx context.Context, args struct {
+		Filter *reportLevelInput
+	}) (int, error) {
+		return 0, nil
+	})
+
+	_, report, err := builder.BuildWithReport()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Input reportLevelInput has no fields with a GraphQL mapping")
+	assert.Equal(t, "tests.reportLevelInput.Level", report.SkippedFields[0].Path)
+}
@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/mirogindev/gomer/gqbuilder"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type Checksum string
+
+var checksumScalar = gqbuilder.NewScalar(gqbuilder.ScalarConfig{
+	Name: "Checksum",
+	Serialize: func(value interface{}) (interface{}, error) {
+		if value.(Checksum) == "bad" {
+			return nil, errors.New("invalid checksum")
+		}
+		return string(value.(Checksum)), nil
+	},
+	ParseValue: func(value interface{}) (interface{}, error) {
+		return Checksum(value.(string)), nil
+	},
+	ParseLiteral: func(valueAST ast.Value) (interface{}, error) {
+		return Checksum(valueAST.(*ast.StringValue).Value), nil
+	},
+})
+
+type scalarErrorsFile struct {
+	Name     string
+	Checksum Checksum
+}
+
+func buildScalarErrorsSchema(called *bool) *gqbuilder.SchemaBuilder {
+	builder := gqbuilder.GetBuilder()
+	builder.RegisterScalarFor(reflect.TypeOf(Checksum("")), checksumScalar)
+	query := builder.Query()
+	query.FieldResolver("files", func(ctx context.Context, args struct {
+		Limit *int64
+		Size  *decimal.Decimal
+	}) ([]*scalarErrorsFile, error) {
+		*called = true
+		return []*scalarErrorsFile{{Name: "a", Checksum: "x1"}, {Name: "b", Checksum: "bad"}}, nil
+	})
+	return builder
+}
+
+func TestScalarParseLiteralErrors(t *testing.T) {
+	called := false
+	schema, err := buildScalarErrorsSchema(&called).Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ files(limit: "abc") { name } }`})
+	assert.False(t, called)
+	assert.Len(t, r.Errors, 1)
+	assert.True(t, strings.HasPrefix(r.Errors[0].Message, `Argument "limit" has invalid value "abc".`), r.Errors[0].Message)
+
+	r = graphql.Do(graphql.Params{Schema: schema, RequestString: `{ files(size: "1.x") { name } }`})
+	assert.False(t, called)
+	assert.Len(t, r.Errors, 1)
+	assert.True(t, strings.HasPrefix(r.Errors[0].Message, `Argument "size" has invalid value "1.x".`), r.Errors[0].Message)
+
+	r = graphql.Do(graphql.Params{Schema: schema, RequestString: `{ files(limit: "10", size: 1.5) { name } }`})
+	assert.True(t, called)
+	assert.Empty(t, r.Errors)
+}
+
+func TestScalarParseValueErrors(t *testing.T) {
+	called := false
+	schema, err := buildScalarErrorsSchema(&called).Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  `query($limit: int64) { files(limit: $limit) { name } }`,
+		VariableValues: map[string]interface{}{"limit": 1.5},
+	})
+	assert.False(t, called)
+	assert.Len(t, r.Errors, 1)
+	assert.True(t, strings.HasPrefix(r.Errors[0].Message, `Variable "$limit" got invalid value 1.5.`), r.Errors[0].Message)
+}
+
+func TestScalarSerializeErrors(t *testing.T) {
+	called := false
+	schema, err := buildScalarErrorsSchema(&called).Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ files { name checksum } }`})
+	assert.Len(t, r.Errors, 1)
+	assert.Equal(t, "Cannot serialize bad as Checksum: invalid checksum", r.Errors[0].Message)
+	assert.Equal(t, []interface{}{"files", 1, "checksum"}, r.Errors[0].Path)
+}
@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"context"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/mirogindev/gomer/gqbuilder"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type Time struct {
+	Zone string
+}
+
+type registryTicketID string
+
+type registryTicket struct {
+	ID        registryTicketID
+	Local     Time
+	CreatedAt time.Time
+}
+
+var registryTicketIDScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name: "TicketID",
+	Serialize: func(value interface{}) interface{} {
+		return "ticket-" + string(value.(registryTicketID))
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return registryTicketID(value.(string))
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		if v, ok := valueAST.(*ast.StringValue); ok {
+			return registryTicketID(v.Value)
+		}
+		return nil
+	},
+})
+
+func TestScalarRegisteredForType(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+	builder.RegisterScalar("registryTicketID", graphql.String)
+	builder.RegisterScalarFor(reflect.TypeOf((*registryTicketID)(nil)), registryTicketIDScalar)
+
+	query := builder.Query()
+	query.FieldResolver("ticket", func(ctx context.Context, args struct {
+		ID registryTicketID
+	}) (*registryTicket, error) {
+		return &registryTicket{ID: args.ID, Local: Time{Zone: "UTC"}, CreatedAt: time.Unix(0, 0).UTC()}, nil
+	})
+
+	schema, err := builder.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, "TicketID", schema.Type("registryTicket").(*graphql.Object).Fields()["id"].Type.(*graphql.NonNull).OfType.Name())
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ ticket(id: "1") { id local { zone } created_at } }`})
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, map[string]interface{}{
+		"id":         "ticket-1",
+		"local":      map[string]interface{}{"zone": "UTC"},
+		"created_at": "1970-01-01T00:00:00Z",
+	}, r.Data.(map[string]interface{})["ticket"])
+}
+
+func TestScalarRegisteredTwiceForType(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+	builder.RegisterScalarFor(reflect.TypeOf(registryTicketID("")), registryTicketIDScalar)
+	builder.RegisterScalarFor(reflect.TypeOf(registryTicketID("")), graphql.String)
+	builder.Query().FieldResolver("ping", func(ctx context.Context) (string, error) {
+		return "pong", nil
+	})
+
+	_, err := builder.Build()
+	assert.EqualError(t, err, "Scalar for type tests.registryTicketID aready exists")
+}
@@ -0,0 +1,226 @@
+package tests
+
+import (
+	"context"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/mirogindev/gomer/gqbuilder"
+	"github.com/mirogindev/gomer/test_uttils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func buildSelectionSchema(selection **gqbuilder.Selection) *gqbuilder.SchemaBuilder {
+	builder := gqbuilder.GetBuilder()
+	builder.Union("SearchResult", (*SearchResult)(nil), Book{}, Author{})
+
+	ticket := builder.Object("Ticket", test_uttils.Ticket{})
+	ticket.FieldResolver("tags", func(ctx context.Context, o *test_uttils.Ticket, args struct {
+		Limit *int
+	}) ([]*test_uttils.Tag, error) {
+		return o.Tags, nil
+	})
+
+	query := builder.Query()
+	query.FieldResolver("tickets", func(ctx context.Context, args struct {
+		Limit *int
+	}) ([]*test_uttils.Ticket, error) {
+		var err error
+		*selection, err = gqbuilder.SelectionFromContext(ctx)
+		return nil, err
+	})
+	query.FieldResolver("search", func(ctx context.Context) ([]SearchResult, error) {
+		var err error
+		*selection, err = gqbuilder.SelectionFromContext(ctx)
+		return nil, err
+	})
+	return builder
+}
+
+func selectionNames(selections []*gqbuilder.Selection) []string {
+	names := make([]string, 0)
+	for _, s := range selections {
+		names = append(names, s.TypeCondition+":"+s.Name)
+	}
+	return names
+}
+
+func TestSelectionFragmentSpread(t *testing.T) {
+	var selection *gqbuilder.Selection
+	schema, err := buildSelectionSchema(&selection).Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `
+		query {
+			tickets(limit: 5) { ...TicketFields, tags { ...TagFields } }
+		}
+
+		fragment TicketFields on Ticket { id, ...TicketTitle }
+		fragment TicketTitle on Ticket { title }
+		fragment TagFields on Tag { title }
+	`})
+	assert.Empty(t, r.Errors)
+
+	fields := selection.SelectionSet.Selections
+	assert.Equal(t, []string{"Ticket:id", "Ticket:title", ":tags"}, selectionNames(fields))
+	assert.Equal(t, []string{"Tag:title"}, selectionNames(fields[2].SelectionSet.Selections))
+}
+
+func TestSelectionUnionFragmentSpread(t *testing.T) {
+	var selection *gqbuilder.Selection
+	schema, err := buildSelectionSchema(&selection).Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `
+		query {
+			search { ...BookFields, ... on Author { ... { id } } }
+		}
+
+		fragment BookFields on Book { title }
+	`})
+	assert.Empty(t, r.Errors)
+
+	fields := selection.SelectionSet.Selections
+	assert.Equal(t, []string{"Book:title", "Author:id"}, selectionNames(fields))
+}
+
+func TestSelectionFragmentCycle(t *testing.T) {
+	var selection *gqbuilder.Selection
+	schema, err := buildSelectionSchema(&selection).Build()
+	assert.Nil(t, err)
+
+	doc, err := parser.Parse(parser.ParseParams{Source: `
+		query {
+			tickets { ...A }
+		}
+
+		fragment A on Ticket { id, ...B }
+		fragment B on Ticket { title, ...A }
+	`})
+	assert.Nil(t, err)
+
+	fragments := map[string]ast.Definition{}
+	var operation ast.Definition
+	for _, d := range doc.Definitions {
+		if fd, ok := d.(*ast.FragmentDefinition); ok {
+			fragments[fd.Name.Value] = fd
+		} else {
+			operation = d.(ast.Definition)
+		}
+	}
+
+	selections, err := gqbuilder.ParseSelections(graphql.ResolveParams{
+		Info: graphql.ResolveInfo{
+			Schema:     schema,
+			Fragments:  fragments,
+			Operation:  operation,
+			ParentType: schema.QueryType(),
+		},
+	}, nil)
+	assert.Nil(t, err)
+
+	assert.Len(t, selections, 1)
+	assert.Equal(t, []string{"Ticket:id", "Ticket:title"}, selectionNames(selections[0].SelectionSet.Selections))
+}
+
+func TestSelectionAliasesAndDirectives(t *testing.T) {
+	var selection *gqbuilder.Selection
+	schema, err := buildSelectionSchema(&selection).Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `
+			query($withTitle: Boolean!, $skipNumber: Boolean!) {
+				tickets {
+					id
+					title @include(if: $withTitle)
+					number @skip(if: $skipNumber)
+					time @skip(if: false)
+					... on Ticket @include(if: false) { time }
+					first: tags(limit: 1) { id }
+					last: tags(limit: 2) { title }
+				}
+			}
+		`,
+		VariableValues: map[string]interface{}{"withTitle": false, "skipNumber": true},
+	})
+	assert.Empty(t, r.Errors)
+
+	fields := selection.SelectionSet.Selections
+	assert.Equal(t, []string{":id", ":time", ":tags", ":tags"}, selectionNames(fields))
+
+	first, last := fields[2], fields[3]
+	assert.Equal(t, "first", first.Alias)
+	assert.Equal(t, "last", last.Alias)
+	assert.Equal(t, 1, *first.Args.(struct{ Limit *int }).Limit)
+	assert.Equal(t, 2, *last.Args.(struct{ Limit *int }).Limit)
+	assert.Equal(t, []string{":title"}, selectionNames(last.SelectionSet.Selections))
+}
+
+func TestSelectionNestedResolver(t *testing.T) {
+	var selection *gqbuilder.Selection
+	builder := gqbuilder.GetBuilder()
+
+	ticket := builder.Object("Ticket", test_uttils.Ticket{})
+	ticket.FieldResolver("tags", func(ctx context.Context, o *test_uttils.Ticket, args struct {
+		Limit *int
+	}) ([]*test_uttils.Tag, error) {
+		var err error
+		selection, err = gqbuilder.SelectionFromContext(ctx)
+		return o.Tags, err
+	})
+
+	query := builder.Query()
+	query.FieldResolver("tickets", func(ctx context.Context) ([]*test_uttils.Ticket, error) {
+		return []*test_uttils.Ticket{{ID: "1"}}, nil
+	})
+
+	schema, err := builder.Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `
+		{
+			tickets { id, tags(limit: 3) { id }, tags(limit: 3) { title } }
+		}
+	`})
+	assert.Empty(t, r.Errors)
+
+	assert.Equal(t, "tags", selection.Name)
+	assert.Equal(t, 3, *selection.Args.(struct{ Limit *int }).Limit)
+	assert.Equal(t, []string{":id", ":title"}, selectionNames(selection.SelectionSet.Selections))
+}
+
+func TestSelectionArgsDecodeError(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+	builder.RegisterScalar("uint8", graphql.Int)
+
+	ticket := builder.Object("Ticket", test_uttils.Ticket{})
+	ticket.FieldResolver("tags", func(ctx context.Context, o *test_uttils.Ticket, args struct {
+		Limit uint8
+	}) ([]*test_uttils.Tag, error) {
+		return o.Tags, nil
+	})
+
+	var selection *gqbuilder.Selection
+	query := builder.Query()
+	query.FieldResolver("tickets", func(ctx context.Context) ([]*test_uttils.Ticket, error) {
+		var err error
+		selection, err = gqbuilder.SelectionFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return []*test_uttils.Ticket{{ID: "1"}}, nil
+	})
+
+	schema, err := builder.Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ tickets { id, tags(limit: 300) { id } } }`})
+	assert.Len(t, r.Errors, 1)
+	assert.Equal(t, "Cannot decode args of field tags, Argument limit value 300 cannot be used as uint8: overflow", r.Errors[0].Message)
+
+	// the part which could be parsed is still returned
+	assert.Equal(t, []string{":id", ":tags"}, selectionNames(selection.SelectionSet.Selections))
+}
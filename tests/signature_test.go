@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"context"
+	"github.com/mirogindev/gomer/gqbuilder"
+	"github.com/mirogindev/gomer/test_uttils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type signatureArgs struct {
+	Limit int
+}
+
+func TestResolverSignatures(t *testing.T) {
+	cases := []struct {
+		name    string
+		handler interface{}
+		message string
+	}{
+		{"ctx", func(ctx context.Context) ([]*test_uttils.Tag, error) { return nil, nil }, ""},
+		{"ctx_args", func(ctx context.Context, args signatureArgs) ([]*test_uttils.Tag, error) { return nil, nil }, ""},
+		{"not_func", "tags",
+			"Resolver not_func of object Query has invalid signature string: handler must be a func, got string, expected func(context.Context[, args struct]) (T, error)"},
+		{"no_ctx", func(args signatureArgs) ([]*test_uttils.Tag, error) { return nil, nil },
+			"Resolver no_ctx of object Query has invalid signature func(tests.signatureArgs) ([]*test_uttils.Tag, error): first param must be context.Context, got tests.signatureArgs, expected func(context.Context[, args struct]) (T, error)"},
+		{"ptr_args", func(ctx context.Context, args *signatureArgs) ([]*test_uttils.Tag, error) { return nil, nil },
+			"Resolver ptr_args of object Query has invalid signature func(context.Context, *tests.signatureArgs) ([]*test_uttils.Tag, error): args param must be a struct, got *tests.signatureArgs, expected func(context.Context[, args struct]) (T, error)"},
+		{"too_many", func(ctx context.Context, args signatureArgs, limit int) ([]*test_uttils.Tag, error) { return nil, nil },
+			"Resolver too_many of object Query has invalid signature func(context.Context, tests.signatureArgs, int) ([]*test_uttils.Tag, error): handler must have 1 or 2 params, got 3, expected func(context.Context[, args struct]) (T, error)"},
+		{"no_error", func(ctx context.Context) ([]*test_uttils.Tag, string) { return nil, "" },
+			"Resolver no_error of object Query has invalid signature func(context.Context) ([]*test_uttils.Tag, string): second result must be error, got string, expected func(context.Context[, args struct]) (T, error)"},
+		{"one_result", func(ctx context.Context) []*test_uttils.Tag { return nil },
+			"Resolver one_result of object Query has invalid signature func(context.Context) []*test_uttils.Tag: handler must return a result and an error, got 1 results, expected func(context.Context[, args struct]) (T, error)"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			builder := gqbuilder.GetBuilder()
+			builder.Query().FieldResolver(c.name, c.handler)
+
+			_, err := builder.Build()
+			if c.message == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, c.message)
+			}
+		})
+	}
+}
+
+func TestObjectResolverSignatures(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+
+	ticket := builder.Object("Ticket", test_uttils.Ticket{})
+	ticket.FieldResolver("parent_args", func(ctx context.Context, o *test_uttils.Ticket, args signatureArgs) (string, error) {
+		return o.Title, nil
+	})
+	ticket.FieldResolver("scalar_parent", func(ctx context.Context, o string) (string, error) {
+		return o, nil
+	})
+	builder.Query().FieldResolver("tickets", func(ctx context.Context) ([]*test_uttils.Ticket, error) {
+		return nil, nil
+	})
+
+	_, err := builder.Build()
+	assert.EqualError(t, err, "Resolver scalar_parent of object Ticket has invalid signature func(context.Context, string) (string, error): "+
+		"parent param must be a struct or a pointer to a struct, got string, expected func(context.Context[, parent T or *T[, args struct]]) (T, error)")
+
+	be := err.(gqbuilder.BuildErrors)[0].(*gqbuilder.BuildError)
+	assert.Equal(t, "Ticket", be.Object)
+	assert.Equal(t, "scalar_parent", be.Field)
+	assert.Equal(t, "func(context.Context, string) (string, error)", be.Signature)
+}
+
+func TestSubscriptionSignatures(t *testing.T) {
+	builder := gqbuilder.GetBuilder()
+	builder.Query().FieldResolver("tags", func(ctx context.Context) ([]*test_uttils.Tag, error) {
+		return nil, nil
+	})
+
+	subscription := builder.Subscription()
+	subscription.FieldSubscription("tags_args", test_uttils.Tag{}, func(ctx context.Context, c chan interface{}, args signatureArgs) {})
+	subscription.FieldSubscription("tags_typed_chan", test_uttils.Tag{}, func(ctx context.Context, c chan test_uttils.Tag) {})
+
+	_, err := builder.Build()
+	assert.EqualError(t, err, "Subscription tags_typed_chan has invalid signature func(context.Context, chan test_uttils.Tag): "+
+		"second param must be chan interface{}, got chan test_uttils.Tag, expected func(context.Context, chan interface{}[, args struct])")
+}
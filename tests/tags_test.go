@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"context"
+	"github.com/graphql-go/graphql"
+	"github.com/mirogindev/gomer/gqbuilder"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type taggedNote struct {
+	ID       int64
+	Title    *string `gomer:"required;description:Title of the note: short and clear"`
+	Body     string  `gomer:"nullable"`
+	Text     string  `gomer:"name:content"`
+	Old      string  `gomer:"deprecated:Use content"`
+	Internal string  `gomer:"-"`
+}
+
+type taggedNoteInput struct {
+	Text     string  `gomer:"name:content;description:Note content"`
+	Title    *string `gomer:"required"`
+	Internal string  `gomer:"-"`
+}
+
+func buildTagsSchema() *gqbuilder.SchemaBuilder {
+	builder := gqbuilder.GetBuilder()
+
+	query := builder.Query()
+	query.FieldResolver("notes", func(ctx context.Context) ([]*taggedNote, error) {
+		title := "Note"
+		return []*taggedNote{{ID: 1, Title: &title, Text: "Text", Internal: "secret"}}, nil
+	})
+
+	mutation := builder.Mutation()
+	mutation.FieldResolver("note_insert", func(ctx context.Context, args struct {
+		Input *taggedNoteInput
+		Limit *int `gomer:"required;description:Max length"`
+	}) (*taggedNote, error) {
+		return &taggedNote{Title: args.Input.Title, Text: args.Input.Text[:*args.Limit]}, nil
+	})
+
+	return builder
+}
+
+func TestTagsOutputFields(t *testing.T) {
+	schema, err := buildTagsSchema().Build()
+	assert.Nil(t, err)
+
+	fields := schema.Type("taggedNote").(*graphql.Object).Fields()
+	assert.Equal(t, "String!", fields["title"].Type.String())
+	assert.Equal(t, "Title of the note: short and clear", fields["title"].Description)
+	assert.Equal(t, "String", fields["body"].Type.String())
+	assert.Equal(t, "String!", fields["content"].Type.String())
+	assert.Equal(t, "Use content", fields["old"].DeprecationReason)
+	assert.NotContains(t, fields, "text")
+	assert.NotContains(t, fields, "internal")
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ notes { id title content } }`})
+	assert.Empty(t, r.Errors)
+	note := r.Data.(map[string]interface{})["notes"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "Note", note["title"])
+	assert.Equal(t, "Text", note["content"])
+}
+
+func TestTagsInputFields(t *testing.T) {
+	schema, err := buildTagsSchema().Build()
+	assert.Nil(t, err)
+
+	fields := schema.Type("taggedNoteInput").(*graphql.InputObject).Fields()
+	assert.Equal(t, "String!", fields["content"].Type.String())
+	assert.Equal(t, "Note content", fields["content"].Description())
+	assert.Equal(t, "String!", fields["title"].Type.String())
+	assert.NotContains(t, fields, "internal")
+
+	for _, a := range schema.MutationType().Fields()["note_insert"].Args {
+		if a.Name() == "limit" {
+			assert.Equal(t, "Int!", a.Type.String())
+			assert.Equal(t, "Max length", a.Description())
+		}
+	}
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `mutation {
+		note_insert(input: {content: "Content", title: "Note"}, limit: 4) { title content }
+	}`})
+	assert.Empty(t, r.Errors)
+	note := r.Data.(map[string]interface{})["note_insert"].(map[string]interface{})
+	assert.Equal(t, "Note", note["title"])
+	assert.Equal(t, "Cont", note["content"])
+}
@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"context"
+	"github.com/graphql-go/graphql"
+	"github.com/mirogindev/gomer/gqbuilder"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type SearchResult interface {
+	IsSearchResult()
+}
+
+type Book struct {
+	ID    string
+	Title string
+	Pages int
+}
+
+type Author struct {
+	ID   string
+	Name string
+}
+
+func (b *Book) IsSearchResult() {}
+
+func (a *Author) IsSearchResult() {}
+
+func buildSearchSchema() *gqbuilder.SchemaBuilder {
+	builder := gqbuilder.GetBuilder()
+	builder.Union("SearchResult", (*SearchResult)(nil), Book{}, Author{})
+
+	query := builder.Query()
+	query.FieldResolver("search", func(ctx context.Context, args struct {
+		Text string
+	}) ([]SearchResult, error) {
+		return []SearchResult{&Book{ID: "1", Title: args.Text, Pages: 3}, &Author{ID: "2", Name: args.Text}}, nil
+	})
+	return builder
+}
+
+func TestUnionQuery(t *testing.T) {
+	schema, err := buildSearchSchema().Build()
+	assert.Nil(t, err)
+
+	query := `
+		{
+			search(text: "found") {
+				__typename
+				... on Book { id, pages }
+				... on Author { name }
+			}
+		}
+	`
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+	assert.Empty(t, r.Errors)
+
+	data := r.Data.(map[string]interface{})
+	results := data["search"].([]interface{})
+	assert.Len(t, results, 2)
+	assert.Equal(t, "Book", results[0].(map[string]interface{})["__typename"])
+	assert.Equal(t, 3, results[0].(map[string]interface{})["pages"])
+	assert.Equal(t, "found", results[1].(map[string]interface{})["name"])
+}
+
+func TestUnionSelection(t *testing.T) {
+	var selection *gqbuilder.Selection
+
+	builder := gqbuilder.GetBuilder()
+	builder.Union("SearchResult", (*SearchResult)(nil), Book{}, Author{})
+
+	query := builder.Query()
+	query.FieldResolver("search", func(ctx context.Context) ([]SearchResult, error) {
+		var err error
+		selection, err = gqbuilder.SelectionFromContext(ctx)
+		return nil, err
+	})
+
+	schema, err := builder.Build()
+	assert.Nil(t, err)
+
+	r := graphql.Do(graphql.Params{Schema: schema, RequestString: `
+		{
+			search { __typename, ... on Book { title }, ... on Author { id, name } }
+		}
+	`})
+	assert.Empty(t, r.Errors)
+
+	assert.Equal(t, "search", selection.Name)
+	fields := selection.SelectionSet.Selections
+	assert.Len(t, fields, 4)
+	assert.Equal(t, "__typename", fields[0].Name)
+	assert.Equal(t, "", fields[0].TypeCondition)
+	assert.Equal(t, "title", fields[1].Name)
+	assert.Equal(t, "Book", fields[1].TypeCondition)
+	assert.Equal(t, "id", fields[2].Name)
+	assert.Equal(t, "Author", fields[2].TypeCondition)
+}